@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type AbilityEndpoint struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Names   []Name `json:"names"`
+	Effects []struct {
+		Effect      string           `json:"effect"`
+		ShortEffect string           `json:"short_effect"`
+		Language    NamedAPIResource `json:"language"`
+	} `json:"effect_entries"`
+}
+
+func fetchAbility(ctx context.Context, config *Config, ability string) (AbilityEndpoint, error) {
+	data := AbilityEndpoint{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/ability/%s/", config.BaseURL, ability), &data)
+	return data, err
+}
+
+// shortEffect returns the English short effect of an ability.
+func (ability AbilityEndpoint) shortEffect() string {
+	for _, effect := range ability.Effects {
+		if effect.Language.Name == "en" {
+			return effect.ShortEffect
+		}
+	}
+	return ""
+}
+
+func commandAbilities(ctx context.Context, config *Config, params ...string) error {
+	if len(params) == 0 {
+		return errors.New("missing pokemon name")
+	}
+	data, err := fetchPokemon(ctx, config, resolveSpecies(ctx, config, params[0]))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Abilities of %s:\n", localName(ctx, config, "pokemon-species", data.Name))
+	for _, slot := range data.Abilities {
+		ability, err := fetchAbility(ctx, config, slot.Ability.Name)
+		if err != nil {
+			return err
+		}
+		hidden := ""
+		if slot.IsHidden {
+			hidden = " (hidden)"
+		}
+		fmt.Printf("- %s%s: %s\n", pickName(config, "ability", ability.Name, ability.Names), hidden, ability.shortEffect())
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type NamedAPIResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type APIResource struct {
+	URL string `json:"url"`
+}
+
+type Name struct {
+	Name     string           `json:"name"`
+	Language NamedAPIResource `json:"language"`
+}
+
+type RegionEndpoint struct {
+	ID        int                `json:"id"`
+	Name      string             `json:"name"`
+	Names     []Name             `json:"names"`
+	Locations []NamedAPIResource `json:"locations"`
+}
+
+type LocationEndpoint struct {
+	ID     int                `json:"id"`
+	Name   string             `json:"name"`
+	Names  []Name             `json:"names"`
+	Region NamedAPIResource   `json:"region"`
+	Areas  []NamedAPIResource `json:"areas"`
+}
+
+// maxSearchResults caps how many areas a search places in their location
+// and region.
+const maxSearchResults = 40
+
+// areaMatch is a location area together with the location and region it
+// belongs to.
+type areaMatch struct {
+	Area     string
+	Location string
+	Region   string
+}
+
+func commandAreas(ctx context.Context, config *Config, params ...string) error {
+	var query, region string
+	for i := 0; i < len(params); i++ {
+		switch params[i] {
+		case "search":
+			if i+1 >= len(params) {
+				return errors.New("missing search term")
+			}
+			i++
+			query = strings.ToLower(params[i])
+		case "--region":
+			if i+1 >= len(params) {
+				return errors.New("missing region name")
+			}
+			i++
+			region = strings.ToLower(params[i])
+		default:
+			return fmt.Errorf("unknown areas option %q", params[i])
+		}
+	}
+	if query == "" && region == "" {
+		return errors.New("use areas search <substring> and/or areas --region <region>")
+	}
+
+	var matches []areaMatch
+	var err error
+	total := 0
+	if region != "" {
+		matches, total, err = regionAreas(ctx, config, region, query)
+	} else {
+		matches, total, err = searchAreas(ctx, config, query)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(matches) == 0 {
+		fmt.Println("No matching location areas.")
+		return nil
+	}
+	for _, match := range matches {
+		fmt.Printf("- %s (location: %s, region: %s)\n", localName(ctx, config, "location-area", match.Area),
+			localName(ctx, config, "location", match.Location), localName(ctx, config, "region", match.Region))
+	}
+	if total > maxSearchResults && region != "" {
+		fmt.Printf("Showing the first %d of %d matching areas, narrow the search.\n", maxSearchResults, total)
+	} else if total > maxSearchResults {
+		fmt.Printf("Showing the first %d of %d matching areas, narrow the search or add --region.\n", maxSearchResults, total)
+	}
+	return nil
+}
+
+// regionAreas walks region -> location -> location-area and returns the
+// areas of the region whose name contains query, along with how many
+// matched in total. Like searchAreas, only the first maxSearchResults are
+// checked against the selected game version.
+func regionAreas(ctx context.Context, config *Config, region, query string) ([]areaMatch, int, error) {
+	regionData := RegionEndpoint{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/region/%s/", config.BaseURL, region), &regionData)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	locations := make([]LocationEndpoint, len(regionData.Locations))
+	err = fetchEach(len(locations), func(i int) error {
+		return fetchJSON(ctx, config, regionData.Locations[i].URL, &locations[i])
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	var found []areaMatch
+	for _, location := range locations {
+		for _, area := range location.Areas {
+			if strings.Contains(area.Name, query) {
+				found = append(found, areaMatch{Area: area.Name, Location: location.Name, Region: regionData.Name})
+			}
+		}
+	}
+	total := len(found)
+	if len(found) > maxSearchResults {
+		found = found[:maxSearchResults]
+	}
+
+	available := make([]bool, len(found))
+	err = fetchEach(len(found), func(i int) error {
+		var err error
+		available[i], err = areaAvailable(ctx, config, found[i].Area)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	var matches []areaMatch
+	for i, match := range found {
+		if available[i] {
+			matches = append(matches, match)
+		}
+	}
+	return matches, total, nil
+}
+
+// searchAreas returns the location areas whose name contains query,
+// resolving the parent location and region of each match, along with how
+// many areas matched in total. Every match takes two requests to place,
+// so only the first maxSearchResults are resolved, a few at a time.
+func searchAreas(ctx context.Context, config *Config, query string) ([]areaMatch, int, error) {
+	names, err := listAreaNames(ctx, config)
+	if err != nil {
+		return nil, 0, err
+	}
+	var found []string
+	for _, name := range names {
+		if strings.Contains(name, query) {
+			found = append(found, name)
+		}
+	}
+	total := len(found)
+	if len(found) > maxSearchResults {
+		found = found[:maxSearchResults]
+	}
+
+	results := make([]areaMatch, len(found))
+	available := make([]bool, len(found))
+	err = fetchEach(len(found), func(i int) error {
+		var err error
+		results[i], available[i], err = areaParents(ctx, config, found[i])
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	var matches []areaMatch
+	for i := range found {
+		if available[i] {
+			matches = append(matches, results[i])
+		}
+	}
+	return matches, total, nil
+}
+
+// listAreaNames returns the names of all location areas, using a first
+// request to learn how many there are.
+func listAreaNames(ctx context.Context, config *Config) ([]string, error) {
+	data := LocationAreaResponse{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/location-area/?offset=0&limit=1", config.BaseURL), &data)
+	if err != nil {
+		return nil, err
+	}
+	err = fetchJSON(ctx, config, fmt.Sprintf("%s/location-area/?offset=0&limit=%d", config.BaseURL, data.Count), &data)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(data.Results))
+	for _, area := range data.Results {
+		names = append(names, area.Name)
+	}
+	return names, nil
+}
+
+// areaParents places an area in its location and region, and reports
+// whether it can be visited in the selected game version.
+func areaParents(ctx context.Context, config *Config, area string) (areaMatch, bool, error) {
+	areaData, err := fetchArea(ctx, config, area)
+	if err != nil {
+		return areaMatch{}, false, err
+	}
+	if !areaInVersion(config, areaData) {
+		return areaMatch{}, false, nil
+	}
+	locationData := LocationEndpoint{}
+	err = fetchJSON(ctx, config, areaData.Location.URL, &locationData)
+	if err != nil {
+		return areaMatch{}, false, err
+	}
+
+	region := locationData.Region.Name
+	if region == "" {
+		region = "unknown"
+	}
+	return areaMatch{Area: area, Location: locationData.Name, Region: region}, true, nil
+}
+
+// areaAvailable reports whether the area can be visited in the selected
+// game version.
+func areaAvailable(ctx context.Context, config *Config, area string) (bool, error) {
+	if config.Version == "" {
+		return true, nil
+	}
+	data, err := fetchArea(ctx, config, area)
+	if err != nil {
+		return false, err
+	}
+	return areaInVersion(config, data), nil
+}
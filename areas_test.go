@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func newAreasConfig(t *testing.T) *Config {
+	t.Helper()
+	return newTestConfig(t, fakeAPI{
+		"/location-area/": map[string]any{
+			"count": 3,
+			"results": []NamedAPIResource{
+				{Name: "lake-verity-area"},
+				{Name: "sinnoh-route-201-area"},
+				{Name: "viridian-forest-area"},
+			},
+		},
+		"/location-area/lake-verity-area/": map[string]any{
+			"location": NamedAPIResource{Name: "lake-verity", URL: "BASE/location/lake-verity/"},
+		},
+		"/location/lake-verity/": LocationEndpoint{
+			Name:   "lake-verity",
+			Region: NamedAPIResource{Name: "sinnoh"},
+			Areas:  []NamedAPIResource{{Name: "lake-verity-area"}},
+		},
+		"/location/sinnoh-route-201/": LocationEndpoint{
+			Name:   "sinnoh-route-201",
+			Region: NamedAPIResource{Name: "sinnoh"},
+			Areas:  []NamedAPIResource{{Name: "sinnoh-route-201-area"}},
+		},
+		"/region/sinnoh/": RegionEndpoint{
+			Name: "sinnoh",
+			Locations: []NamedAPIResource{
+				{Name: "lake-verity", URL: "BASE/location/lake-verity/"},
+				{Name: "sinnoh-route-201", URL: "BASE/location/sinnoh-route-201/"},
+			},
+		},
+	})
+}
+
+func TestSearchAreas(t *testing.T) {
+	config := newAreasConfig(t)
+
+	matches, total, err := searchAreas(context.Background(), config, "lake")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []areaMatch{{Area: "lake-verity-area", Location: "lake-verity", Region: "sinnoh"}}
+	if !reflect.DeepEqual(matches, expected) || total != 1 {
+		t.Errorf("expected %v out of 1, got %v out of %d", expected, matches, total)
+	}
+}
+
+func TestSearchAreasCapsResults(t *testing.T) {
+	routes := maxSearchResults + 10
+	fake := fakeAPI{}
+	var results []NamedAPIResource
+	for i := 1; i <= routes; i++ {
+		area, location := fmt.Sprintf("route-%d-area", i), fmt.Sprintf("route-%d", i)
+		results = append(results, NamedAPIResource{Name: area})
+		fake["/location-area/"+area+"/"] = map[string]any{
+			"location": NamedAPIResource{Name: location, URL: "BASE/location/" + location + "/"},
+		}
+		fake["/location/"+location+"/"] = LocationEndpoint{Name: location, Region: NamedAPIResource{Name: "kanto"}}
+	}
+	fake["/location-area/"] = map[string]any{"count": routes, "results": results}
+	config := newTestConfig(t, fake)
+
+	matches, total, err := searchAreas(context.Background(), config, "route")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != routes || len(matches) != maxSearchResults {
+		t.Fatalf("expected %d of %d matches, got %d of %d", maxSearchResults, routes, len(matches), total)
+	}
+	for i, match := range matches {
+		if match.Location != fmt.Sprintf("route-%d", i+1) {
+			t.Errorf("expected matches in list order, got %s at %d", match.Location, i)
+		}
+	}
+}
+
+func TestRegionAreas(t *testing.T) {
+	config := newAreasConfig(t)
+
+	cases := []struct {
+		query    string
+		expected []areaMatch
+	}{
+		{
+			query: "",
+			expected: []areaMatch{
+				{Area: "lake-verity-area", Location: "lake-verity", Region: "sinnoh"},
+				{Area: "sinnoh-route-201-area", Location: "sinnoh-route-201", Region: "sinnoh"},
+			},
+		},
+		{
+			query:    "route",
+			expected: []areaMatch{{Area: "sinnoh-route-201-area", Location: "sinnoh-route-201", Region: "sinnoh"}},
+		},
+	}
+	for _, c := range cases {
+		matches, total, err := regionAreas(context.Background(), config, "sinnoh", c.query)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(matches, c.expected) || total != len(c.expected) {
+			t.Errorf("query %q: expected %v, got %v out of %d", c.query, c.expected, matches, total)
+		}
+	}
+}
+
+func TestRegionAreasCapsResults(t *testing.T) {
+	routes := maxSearchResults + 10
+	fake := fakeAPI{}
+	var locations []NamedAPIResource
+	for i := 1; i <= routes; i++ {
+		area, location := fmt.Sprintf("route-%d-area", i), fmt.Sprintf("route-%d", i)
+		locations = append(locations, NamedAPIResource{Name: location, URL: "BASE/location/" + location + "/"})
+		fake["/location/"+location+"/"] = LocationEndpoint{Name: location, Areas: []NamedAPIResource{{Name: area}}}
+	}
+	fake["/region/kanto/"] = RegionEndpoint{Name: "kanto", Locations: locations}
+	config := newTestConfig(t, fake)
+
+	matches, total, err := regionAreas(context.Background(), config, "kanto", "route")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != routes || len(matches) != maxSearchResults {
+		t.Fatalf("expected %d of %d matches, got %d of %d", maxSearchResults, routes, len(matches), total)
+	}
+	for i, match := range matches {
+		if match.Location != fmt.Sprintf("route-%d", i+1) {
+			t.Errorf("expected matches in region order, got %s at %d", match.Location, i)
+		}
+	}
+}
@@ -0,0 +1,509 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+// Battle is an ongoing battle against the wild Pokemon in Config.Wild.
+type Battle struct {
+	Active      int // ID of the owned Pokemon fighting
+	RunAttempts int
+}
+
+// struggleMove is used by a Pokemon that knows no moves.
+const struggleMove = "struggle"
+
+var errNotInBattle = errors.New("you are not in a battle, use battle first")
+var errInBattle = errors.New("you can't do that during a battle")
+
+// battler is one side of a battle, with everything the damage formula
+// needs.
+type battler struct {
+	Name  string
+	Level int
+	Types []string
+	Stats map[string]int
+	HP    int
+	Moves []string
+	owned *OwnedPokemon // nil for the wild Pokemon
+}
+
+func ownedBattler(ctx context.Context, config *Config, owned *OwnedPokemon) (*battler, error) {
+	data, err := fetchPokemon(ctx, config, owned.Species)
+	if err != nil {
+		return nil, err
+	}
+	nature, err := fetchNature(ctx, config, owned.Nature)
+	if err != nil {
+		return nil, err
+	}
+	// Pokemon caught before they had moves learn theirs on their first
+	// battle.
+	if len(owned.Moves) == 0 {
+		versionGroup, err := currentVersionGroup(ctx, config)
+		if err != nil {
+			return nil, err
+		}
+		owned.Moves = startingMoves(data, versionGroup, owned.Level)
+	}
+
+	types, err := typesOf(ctx, config, data)
+	if err != nil {
+		return nil, err
+	}
+	stats := computeStats(data, owned, nature)
+	return &battler{
+		Name:  ownedName(ctx, config, owned),
+		Level: owned.Level,
+		Types: types,
+		Stats: stats,
+		HP:    max(stats["hp"]-owned.Damage, 0),
+		Moves: owned.Moves,
+		owned: owned,
+	}, nil
+}
+
+func wildBattler(ctx context.Context, config *Config) (*battler, error) {
+	wild := config.Wild
+	data, err := fetchPokemon(ctx, config, wild.Name)
+	if err != nil {
+		return nil, err
+	}
+	versionGroup, err := currentVersionGroup(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	types, err := typesOf(ctx, config, data)
+	if err != nil {
+		return nil, err
+	}
+	return &battler{
+		Name:  "the wild " + localName(ctx, config, "pokemon-species", wild.Name),
+		Level: wild.Level,
+		Types: types,
+		Stats: computeStats(data, &OwnedPokemon{Level: wild.Level}, NatureEndpoint{}),
+		HP:    wild.HP,
+		Moves: startingMoves(data, versionGroup, wild.Level),
+	}, nil
+}
+
+// sync writes the HP of b back to the Pokemon it was built from.
+func (b *battler) sync(config *Config) {
+	if b.owned != nil {
+		b.owned.Damage = b.Stats["hp"] - b.HP
+		return
+	}
+	config.Wild.HP = b.HP
+}
+
+// battlers returns both sides of the current battle.
+func battlers(ctx context.Context, config *Config) (player, wild *battler, err error) {
+	if config.Battle == nil {
+		return nil, nil, errNotInBattle
+	}
+	player, err = ownedBattler(ctx, config, config.Owned[config.Battle.Active])
+	if err != nil {
+		return nil, nil, err
+	}
+	wild, err = wildBattler(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return player, wild, nil
+}
+
+// firstAlive returns the first party member other than except that can
+// still fight, or 0 if there is none.
+func firstAlive(ctx context.Context, config *Config, except int) (int, error) {
+	for _, id := range config.Party {
+		if id == except {
+			continue
+		}
+		b, err := ownedBattler(ctx, config, config.Owned[id])
+		if err != nil {
+			return 0, err
+		}
+		if b.HP > 0 {
+			return id, nil
+		}
+	}
+	return 0, nil
+}
+
+func commandBattle(ctx context.Context, config *Config) error {
+	if config.Battle != nil {
+		return errors.New("you are already in a battle")
+	}
+	if config.Wild == nil || config.Wild.Area != config.CurrentArea {
+		return errNoWildPokemon
+	}
+	lead, err := firstAlive(ctx, config, 0)
+	if err != nil {
+		return err
+	}
+	if lead == 0 {
+		return errors.New("you have no pokemon in your party that can fight")
+	}
+
+	config.Battle = &Battle{Active: lead}
+	player, wild, err := battlers(ctx, config)
+	if err != nil {
+		config.Battle = nil
+		return err
+	}
+	fmt.Printf("You challenge %s (Lv. %d)! Go, %s!\n", wild.Name, wild.Level, player.Name)
+	return printBattleMoves(ctx, config, player)
+}
+
+func printBattleMoves(ctx context.Context, config *Config, player *battler) error {
+	fmt.Printf("%s has %d/%d HP. Moves:\n", player.Name, player.HP, player.Stats["hp"])
+	for _, name := range player.Moves {
+		move, err := fetchMove(ctx, config, name)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("- %s (%s, %s, power %d, accuracy %d)\n", pickName(config, "move", move.Name, move.Names),
+			localName(ctx, config, "type", move.Type.Name), move.DamageClass.Name, move.Power, move.Accuracy)
+	}
+	fmt.Println("Use fight <move>, switch <id>, catch or run.")
+	return nil
+}
+
+func commandFight(ctx context.Context, config *Config, params ...string) error {
+	if len(params) == 0 {
+		return errors.New("missing move name")
+	}
+	player, wild, err := battlers(ctx, config)
+	if err != nil {
+		return err
+	}
+	if player.HP == 0 {
+		return fmt.Errorf("%s has fainted, use switch <id>", player.Name)
+	}
+	move := resolveNameAmong(ctx, config, "move", params[0], player.Moves)
+	if !knowsMove(player, move) {
+		return fmt.Errorf("%s does not know %s", player.Name, params[0])
+	}
+
+	playerMove, err := fetchMove(ctx, config, move)
+	if err != nil {
+		return err
+	}
+	wildMove, err := fetchMove(ctx, config, chooseMove(config.Rand, wild))
+	if err != nil {
+		return err
+	}
+
+	first, second := player, wild
+	firstMove, secondMove := playerMove, wildMove
+	if movesSecond(config.Rand, player, wild, playerMove, wildMove) {
+		first, second = wild, player
+		firstMove, secondMove = wildMove, playerMove
+	}
+	err = useMove(ctx, config, first, second, firstMove)
+	if err != nil {
+		return err
+	}
+	if second.HP > 0 {
+		err = useMove(ctx, config, second, first, secondMove)
+		if err != nil {
+			return err
+		}
+	}
+
+	player.sync(config)
+	wild.sync(config)
+	return endTurn(ctx, config, player, wild)
+}
+
+func knowsMove(b *battler, move string) bool {
+	if len(b.Moves) == 0 {
+		return move == struggleMove
+	}
+	for _, known := range b.Moves {
+		if known == move {
+			return true
+		}
+	}
+	return false
+}
+
+func chooseMove(rng *rand.Rand, b *battler) string {
+	if len(b.Moves) == 0 {
+		return struggleMove
+	}
+	return b.Moves[rng.Intn(len(b.Moves))]
+}
+
+// movesSecond reports whether the player acts after the wild Pokemon:
+// higher priority goes first, then higher speed, with ties broken at
+// random.
+func movesSecond(rng *rand.Rand, player, wild *battler, playerMove, wildMove MoveEndpoint) bool {
+	if playerMove.Priority != wildMove.Priority {
+		return playerMove.Priority < wildMove.Priority
+	}
+	if player.Stats["speed"] != wild.Stats["speed"] {
+		return player.Stats["speed"] < wild.Stats["speed"]
+	}
+	return rng.Intn(2) == 0
+}
+
+func useMove(ctx context.Context, config *Config, attacker, defender *battler, move MoveEndpoint) error {
+	fmt.Printf("%s used %s!\n", attacker.Name, pickName(config, "move", move.Name, move.Names))
+	if move.Accuracy > 0 && config.Rand.Intn(100) >= move.Accuracy {
+		fmt.Println("But it missed!")
+		return nil
+	}
+	if move.Power == 0 {
+		// Status moves and their effects are not simulated.
+		fmt.Println("But nothing happened.")
+		return nil
+	}
+
+	multiplier, err := typeMultiplier(ctx, config, move.Type.Name, defender.Types)
+	if err != nil {
+		return err
+	}
+	damage, critical := calcDamage(config.Rand, attacker, defender, move, multiplier)
+	switch {
+	case multiplier == 0:
+		fmt.Printf("It doesn't affect %s...\n", defender.Name)
+		return nil
+	case critical:
+		fmt.Println("A critical hit!")
+	}
+	switch {
+	case multiplier > 1:
+		fmt.Println("It's super effective!")
+	case multiplier < 1:
+		fmt.Println("It's not very effective...")
+	}
+	defender.HP = max(defender.HP-damage, 0)
+	fmt.Printf("%s has %d/%d HP left.\n", defender.Name, defender.HP, defender.Stats["hp"])
+	return nil
+}
+
+// calcDamage applies the standard damage formula with a random factor,
+// same-type attack bonus, type effectiveness and critical hits.
+func calcDamage(rng *rand.Rand, attacker, defender *battler, move MoveEndpoint, multiplier float64) (int, bool) {
+	attack, defense := attacker.Stats["attack"], defender.Stats["defense"]
+	if move.DamageClass.Name == "special" {
+		attack, defense = attacker.Stats["special-attack"], defender.Stats["special-defense"]
+	}
+	base := (2*attacker.Level/5+2)*move.Power*attack/max(defense, 1)/50 + 2
+
+	modifier := multiplier * float64(85+rng.Intn(16)) / 100
+	for _, t := range attacker.Types {
+		if t == move.Type.Name {
+			modifier *= 1.5
+			break
+		}
+	}
+	critical := rng.Intn(24) == 0
+	if critical {
+		modifier *= 1.5
+	}
+
+	damage := int(float64(base) * modifier)
+	if damage < 1 && multiplier > 0 {
+		damage = 1
+	}
+	return damage, critical
+}
+
+// endTurn ends the battle if either side fainted.
+func endTurn(ctx context.Context, config *Config, player, wild *battler) error {
+	if wild.HP == 0 {
+		fmt.Printf("%s fainted!\n", wild.Name)
+		reward(config, 10*wild.Level, "winning the battle")
+		defeated, err := fetchPokemon(ctx, config, config.Wild.Name)
+		if err != nil {
+			return err
+		}
+		winner := config.Owned[config.Battle.Active]
+		config.Battle = nil
+		config.Wild = nil
+		return gainExperience(ctx, config, winner, defeated, wild.Level)
+	}
+	if player.HP > 0 {
+		return nil
+	}
+
+	fmt.Printf("%s fainted!\n", player.Name)
+	next, err := firstAlive(ctx, config, config.Battle.Active)
+	if err != nil {
+		return err
+	}
+	if next != 0 {
+		fmt.Println("Send out your next pokemon with switch <id>.")
+		return nil
+	}
+	fmt.Println("You have no pokemon left that can fight. You blacked out!")
+	config.Battle = nil
+	config.Wild = nil
+	return nil
+}
+
+// wildTurn lets the wild Pokemon attack after the player spent their turn
+// on something other than a move.
+func wildTurn(ctx context.Context, config *Config) error {
+	player, wild, err := battlers(ctx, config)
+	if err != nil {
+		return err
+	}
+	if player.HP == 0 {
+		return nil
+	}
+	move, err := fetchMove(ctx, config, chooseMove(config.Rand, wild))
+	if err != nil {
+		return err
+	}
+	err = useMove(ctx, config, wild, player, move)
+	if err != nil {
+		return err
+	}
+	player.sync(config)
+	return endTurn(ctx, config, player, wild)
+}
+
+func commandSwitch(ctx context.Context, config *Config, params ...string) error {
+	if config.Battle == nil {
+		return errNotInBattle
+	}
+	id, err := parseID(params)
+	if err != nil {
+		return err
+	}
+	box, _, err := locatePokemon(config, id)
+	if err != nil {
+		return err
+	}
+	if box >= 0 {
+		return fmt.Errorf("#%d is not in your party", id)
+	}
+	if id == config.Battle.Active {
+		return fmt.Errorf("#%d is already fighting", id)
+	}
+	next, err := ownedBattler(ctx, config, config.Owned[id])
+	if err != nil {
+		return err
+	}
+	if next.HP == 0 {
+		return fmt.Errorf("%s has fainted and can't fight", next.Name)
+	}
+	current, err := ownedBattler(ctx, config, config.Owned[config.Battle.Active])
+	if err != nil {
+		return err
+	}
+
+	config.Battle.Active = id
+	if current.HP == 0 {
+		fmt.Printf("Go, %s!\n", next.Name)
+		return printBattleMoves(ctx, config, next)
+	}
+	fmt.Printf("Come back, %s! Go, %s!\n", current.Name, next.Name)
+	err = printBattleMoves(ctx, config, next)
+	if err != nil {
+		return err
+	}
+	return wildTurn(ctx, config)
+}
+
+func commandRun(ctx context.Context, config *Config) error {
+	player, wild, err := battlers(ctx, config)
+	if err != nil {
+		return err
+	}
+	config.Battle.RunAttempts++
+	if escapes(config.Rand, player.Stats["speed"], wild.Stats["speed"], config.Battle.RunAttempts) {
+		fmt.Println("Got away safely!")
+		config.Battle = nil
+		config.Wild = nil
+		return nil
+	}
+	fmt.Println("You can't escape!")
+	return wildTurn(ctx, config)
+}
+
+// escapes applies the generation III escape formula.
+func escapes(rng *rand.Rand, playerSpeed, wildSpeed, attempts int) bool {
+	if playerSpeed >= wildSpeed {
+		return true
+	}
+	odds := (playerSpeed*128/max(wildSpeed, 1) + 30*attempts) % 256
+	return rng.Intn(256) < odds
+}
+
+// healAmounts is how much HP each healing item restores, where 0 means
+// all of it.
+var healAmounts = map[string]int{
+	"potion":       20,
+	"super-potion": 60,
+	"hyper-potion": 120,
+	"max-potion":   0,
+	"revive":       0,
+}
+
+// useHealingItem heals one of the trainer's Pokemon. In battle it costs
+// the turn and defaults to the Pokemon that is fighting.
+func useHealingItem(ctx context.Context, config *Config, item string, params []string) error {
+	var owned *OwnedPokemon
+	switch {
+	case len(params) > 0:
+		var err error
+		owned, err = findOwned(config, params[0])
+		if err != nil {
+			return err
+		}
+	case config.Battle != nil:
+		owned = config.Owned[config.Battle.Active]
+	default:
+		return fmt.Errorf("use %s <pokemon>", item)
+	}
+
+	target, err := ownedBattler(ctx, config, owned)
+	if err != nil {
+		return err
+	}
+	maxHP := target.Stats["hp"]
+	switch {
+	case item == "revive" && target.HP > 0:
+		return fmt.Errorf("%s has not fainted", target.Name)
+	case item == "revive":
+		target.HP = maxHP / 2
+	case target.HP == 0:
+		return fmt.Errorf("%s has fainted, it needs a revive", target.Name)
+	case target.HP == maxHP:
+		return fmt.Errorf("%s already has full HP", target.Name)
+	case healAmounts[item] == 0:
+		target.HP = maxHP
+	default:
+		target.HP = min(target.HP+healAmounts[item], maxHP)
+	}
+
+	err = takeItem(config, item)
+	if err != nil {
+		return err
+	}
+	target.sync(config)
+	fmt.Printf("%s now has %d/%d HP.\n", target.Name, target.HP, maxHP)
+	if config.Battle != nil {
+		return wildTurn(ctx, config)
+	}
+	return nil
+}
+
+// commandHeal restores every Pokemon in the party at a Pokemon Center.
+func commandHeal(config *Config) error {
+	if config.Battle != nil {
+		return errInBattle
+	}
+	for _, id := range config.Party {
+		config.Owned[id].Damage = 0
+	}
+	fmt.Println("Your pokemon are fighting fit!")
+	return nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+func baseStats(hp, attack, defense, spAttack, spDefense, speed int) []map[string]any {
+	values := []int{hp, attack, defense, spAttack, spDefense, speed}
+	stats := make([]map[string]any, 0, len(values))
+	for i, value := range values {
+		stats = append(stats, map[string]any{"base_stat": value, "stat": NamedAPIResource{Name: statNames[i]}})
+	}
+	return stats
+}
+
+func levelUpMoveData(move string, level int) map[string]any {
+	return map[string]any{
+		"move": NamedAPIResource{Name: move},
+		"version_group_details": []map[string]any{{
+			"level_learned_at":  level,
+			"move_learn_method": NamedAPIResource{Name: "level-up"},
+			"version_group":     NamedAPIResource{Name: "red-blue"},
+		}},
+	}
+}
+
+// newBattleConfig sets up a level 20 pikachu in the party facing a wild
+// level 3 pidgey in viridian forest.
+func newBattleConfig(t *testing.T) *Config {
+	t.Helper()
+	config := newTestConfig(t, fakeAPI{
+		"/pokemon/pikachu/": map[string]any{
+			"name":    "pikachu",
+			"species": NamedAPIResource{Name: "pikachu", URL: "BASE/pokemon-species/pikachu/"},
+			"types":   []map[string]any{{"slot": 1, "type": NamedAPIResource{Name: "electric"}}},
+			"stats":   baseStats(35, 55, 40, 50, 50, 90),
+			"moves":   []map[string]any{levelUpMoveData("thunder-shock", 1), levelUpMoveData("thunderbolt", 21)},
+		},
+		"/pokemon/pidgey/": map[string]any{
+			"name":            "pidgey",
+			"base_experience": 50,
+			"types":           []map[string]any{{"slot": 1, "type": NamedAPIResource{Name: "normal"}}, {"slot": 2, "type": NamedAPIResource{Name: "flying"}}},
+			"stats":           baseStats(40, 45, 40, 35, 35, 56),
+			"moves":           []map[string]any{levelUpMoveData("tackle", 1)},
+		},
+		"/pokemon-species/pikachu/": map[string]any{
+			"name":        "pikachu",
+			"growth_rate": NamedAPIResource{Name: "medium", URL: "BASE/growth-rate/medium/"},
+		},
+		"/growth-rate/medium/": mediumGrowthRate(),
+		"/nature/hardy/":       NatureEndpoint{Name: "hardy", IncreasedStat: NamedAPIResource{Name: "attack"}, DecreasedStat: NamedAPIResource{Name: "attack"}},
+		"/move/thunder-shock/": MoveEndpoint{Name: "thunder-shock", Power: 40, Accuracy: 100, Type: NamedAPIResource{Name: "electric"}, DamageClass: NamedAPIResource{Name: "special"}},
+		"/move/tackle/":        MoveEndpoint{Name: "tackle", Power: 40, Accuracy: 100, Type: NamedAPIResource{Name: "normal"}, DamageClass: NamedAPIResource{Name: "physical"}},
+		"/type/":               NamedAPIResourceList{Results: []NamedAPIResource{{Name: "electric"}, {Name: "normal"}}},
+		"/type/electric/":      electricType(),
+		"/type/normal/":        TypeEndpoint{Name: "normal"},
+	})
+	config.CurrentArea = "viridian-forest-area"
+	config.Wild = &WildPokemon{Name: "pidgey", Level: 3, HP: 16, MaxHP: 16, Area: "viridian-forest-area"}
+	config.Owned[1] = &OwnedPokemon{ID: 1, Species: "pikachu", Level: 20, Nature: "hardy"}
+	config.Party = []int{1}
+	return config
+}
+
+func electricType() TypeEndpoint {
+	electric := TypeEndpoint{Name: "electric"}
+	electric.DamageRelations.DoubleDamageTo = []NamedAPIResource{{Name: "flying"}, {Name: "water"}}
+	electric.DamageRelations.HalfDamageTo = []NamedAPIResource{{Name: "grass"}, {Name: "electric"}}
+	electric.DamageRelations.NoDamageTo = []NamedAPIResource{{Name: "ground"}}
+	return electric
+}
+
+func TestCalcDamage(t *testing.T) {
+	attacker := &battler{Level: 50, Types: []string{"electric"}, Stats: map[string]int{"special-attack": 100}}
+	defender := &battler{Stats: map[string]int{"special-defense": 100}}
+	move := MoveEndpoint{Power: 90, Type: NamedAPIResource{Name: "electric"}, DamageClass: NamedAPIResource{Name: "special"}}
+
+	// Base damage is (22 * 90 * 100 / 100) / 50 + 2 = 41, times 1.5 STAB and
+	// 2 for effectiveness, times a random 0.85 to 1, and 1.5 on a critical.
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		damage, critical := calcDamage(rng, attacker, defender, move, 2)
+		low, high := 104, 123
+		if critical {
+			low, high = 156, 184
+		}
+		if damage < low || damage > high {
+			t.Fatalf("expected damage between %d and %d, got %d", low, high, damage)
+		}
+	}
+
+	if damage, _ := calcDamage(rng, attacker, defender, move, 0); damage != 0 {
+		t.Errorf("expected no damage against an immune type, got %d", damage)
+	}
+}
+
+func TestBattleWin(t *testing.T) {
+	config := newBattleConfig(t)
+	ctx := context.Background()
+
+	if err := commandBattle(ctx, config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := commandFight(ctx, config, "tackle"); err == nil {
+		t.Error("expected an error using a move pikachu does not know")
+	}
+	money := config.Money
+	for i := 0; config.Battle != nil; i++ {
+		if i == 10 {
+			t.Fatal("expected the battle to be over after 10 turns")
+		}
+		if err := commandFight(ctx, config, "thunder-shock"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if config.Wild != nil {
+		t.Error("expected the fainted wild pokemon to be gone")
+	}
+	if config.Money != money+30 {
+		t.Errorf("expected $30 for winning, got $%d", config.Money-money)
+	}
+}
+
+func TestBattleRequiresWildPokemon(t *testing.T) {
+	config := newBattleConfig(t)
+	config.Wild = nil
+
+	if err := commandBattle(context.Background(), config); err == nil {
+		t.Error("expected an error without a wild pokemon")
+	}
+	if err := commandFight(context.Background(), config, "thunder-shock"); err == nil {
+		t.Error("expected an error fighting outside a battle")
+	}
+}
+
+func TestEscapes(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	if !escapes(rng, 90, 56, 1) {
+		t.Error("expected a faster pokemon to always escape")
+	}
+	// 10 * 128 / 200 + 30 * 8 = 246, so only rolls of 246 to 255 fail.
+	escaped := 0
+	for i := 0; i < 1000; i++ {
+		if escapes(rng, 10, 200, 8) {
+			escaped++
+		}
+	}
+	if escaped < 900 {
+		t.Errorf("expected about 96%% of escapes to succeed, got %d out of 1000", escaped)
+	}
+}
+
+func TestHealingItems(t *testing.T) {
+	config := newBattleConfig(t)
+	config.Inventory = map[string]int{"potion": 1, "revive": 1}
+	owned := config.Owned[1]
+	ctx := context.Background()
+
+	owned.Damage = 30
+	if err := commandUse(ctx, config, "potion", "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owned.Damage != 10 {
+		t.Errorf("expected a potion to heal 20 HP, got damage %d", owned.Damage)
+	}
+	if err := commandUse(ctx, config, "revive", "1"); err == nil {
+		t.Error("expected an error reviving a pokemon that has not fainted")
+	}
+	if err := commandHeal(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owned.Damage != 0 {
+		t.Errorf("expected heal to restore all HP, got damage %d", owned.Damage)
+	}
+}
+
+// mediumGrowthRate returns the medium fast growth rate, where reaching
+// level n takes n cubed experience.
+func mediumGrowthRate() GrowthRateEndpoint {
+	growth := GrowthRateEndpoint{Name: "medium"}
+	for level := 1; level <= maxLevel; level++ {
+		growth.Levels = append(growth.Levels, struct {
+			Level      int `json:"level"`
+			Experience int `json:"experience"`
+		}{Level: level, Experience: level * level * level})
+	}
+	return growth
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// ballModifiers holds the catch rate modifier of each supported ball.
+var ballModifiers = map[string]float64{
+	"poke-ball":   1,
+	"great-ball":  1.5,
+	"ultra-ball":  2,
+	"master-ball": 255,
+}
+
+// statusModifiers holds the catch rate modifier of each major status
+// condition, as in generations III and IV.
+var statusModifiers = map[string]float64{
+	"sleep":    2,
+	"freeze":   2,
+	"paralyze": 1.5,
+	"poison":   1.5,
+	"burn":     1.5,
+}
+
+func commandCatch(ctx context.Context, config *Config, params ...string) error {
+	pokemon, ball := "", "poke-ball"
+	for i := 0; i < len(params); i++ {
+		if params[i] == "--ball" {
+			if i+1 >= len(params) {
+				return errors.New("missing ball name")
+			}
+			i++
+			ball = resolveNameAmong(ctx, config, "item", params[i], bagItems(config))
+			continue
+		}
+		pokemon = resolveNameAmong(ctx, config, "pokemon-species", params[i], knownSpecies(config))
+	}
+	if _, ok := ballModifiers[ball]; !ok {
+		return fmt.Errorf("unknown ball %q", ball)
+	}
+	if config.Inventory[ball] <= 0 {
+		return fmt.Errorf("you have no %s left", ball)
+	}
+	if pokemon == "" {
+		if config.Wild == nil {
+			return errNoWildPokemon
+		}
+		pokemon = config.Wild.Name
+	}
+	if config.Battle == nil {
+		return catchPokemon(ctx, config, pokemon, ball)
+	}
+
+	if pokemon != config.Wild.Name {
+		return fmt.Errorf("you are battling %s, not %s", config.Wild.Name, pokemon)
+	}
+	err := catchPokemon(ctx, config, pokemon, ball)
+	if err != nil {
+		return err
+	}
+	if config.Wild == nil {
+		config.Battle = nil
+		return nil
+	}
+	return wildTurn(ctx, config)
+}
+
+func catchPokemon(ctx context.Context, config *Config, pokemon, ball string) error {
+	if !config.FreeMode {
+		err := checkEncountered(ctx, config, pokemon)
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := fetchPokemon(ctx, config, pokemon)
+	if err != nil {
+		return err
+	}
+	species, err := fetchSpecies(ctx, config, data)
+	if err != nil {
+		return err
+	}
+
+	err = takeItem(config, ball)
+	if err != nil {
+		return err
+	}
+
+	// A Pokemon caught by name rather than encountered is at full health.
+	hp, maxHP, status, level := 1, 1, "", defaultCatchLevel
+	if wildInArea(config, data.Name) {
+		hp, maxHP, status, level = config.Wild.HP, config.Wild.MaxHP, config.Wild.Status, config.Wild.Level
+	}
+
+	name := localName(ctx, config, "pokemon-species", data.Name)
+	fmt.Printf("Throwing a %s at %s... (%d left)\n", localName(ctx, config, "item", ball), name, config.Inventory[ball])
+	shakes := rollShakes(config.Rand, catchValue(species.CaptureRate, hp, maxHP, ball, status))
+	for i := 0; i < min(shakes, 3); i++ {
+		fmt.Println("...shake...")
+	}
+	if shakes < 4 {
+		fmt.Println(name, "broke free!")
+		return nil
+	}
+
+	owned, err := newOwnedPokemon(ctx, config, data, species, level)
+	if err != nil {
+		return err
+	}
+	(*config.Pokedex)[data.Name] = data
+	fmt.Printf("Click! You successfully caught a %s (Lv. %d, ID %d)\n", name, owned.Level, owned.ID)
+	fmt.Printf("%s was sent to %s.\n", name, storePokemon(config, owned.ID))
+	reward(config, data.BaseExperience, "catching "+name)
+	if wildInArea(config, data.Name) {
+		config.Wild = nil
+	}
+	return nil
+}
+
+// catchValue returns the modified catch rate of the generation III+ catch
+// formula. A value of 255 or more is a guaranteed catch.
+func catchValue(captureRate, hp, maxHP int, ball, status string) float64 {
+	if ball == "master-ball" {
+		return 255
+	}
+	statusModifier, ok := statusModifiers[status]
+	if !ok {
+		statusModifier = 1
+	}
+	hpFactor := float64(3*maxHP-2*hp) / float64(3*maxHP)
+	return hpFactor * float64(captureRate) * ballModifiers[ball] * statusModifier
+}
+
+// shakeThreshold returns the value each of the four shake checks has to
+// stay under, out of 65536.
+func shakeThreshold(a float64) int {
+	if a >= 255 {
+		return 65536
+	}
+	return int(1048560 / math.Sqrt(math.Sqrt(16711680/a)))
+}
+
+// rollShakes returns how many of the four shake checks pass; all four
+// passing means the Pokemon is caught.
+func rollShakes(rng *rand.Rand, a float64) int {
+	threshold := shakeThreshold(a)
+	shakes := 0
+	for shakes < 4 && rng.Intn(65536) < threshold {
+		shakes++
+	}
+	return shakes
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCatchValue(t *testing.T) {
+	cases := []struct {
+		captureRate int
+		hp, maxHP   int
+		ball        string
+		status      string
+		expected    float64
+	}{
+		{captureRate: 255, hp: 30, maxHP: 30, ball: "poke-ball", expected: 85},
+		{captureRate: 255, hp: 30, maxHP: 30, ball: "great-ball", expected: 127.5},
+		{captureRate: 45, hp: 1, maxHP: 1, ball: "ultra-ball", expected: 30},
+		{captureRate: 3, hp: 100, maxHP: 100, ball: "poke-ball", status: "sleep", expected: 2},
+		{captureRate: 3, hp: 100, maxHP: 100, ball: "master-ball", expected: 255},
+		{captureRate: 90, hp: 0, maxHP: 30, ball: "poke-ball", status: "paralyze", expected: 135},
+	}
+	for _, c := range cases {
+		got := catchValue(c.captureRate, c.hp, c.maxHP, c.ball, c.status)
+		if math.Abs(got-c.expected) > 1e-9 {
+			t.Errorf("catchValue(%d, %d, %d, %s, %q): expected %v, got %v",
+				c.captureRate, c.hp, c.maxHP, c.ball, c.status, c.expected, got)
+		}
+	}
+}
+
+func TestShakeThreshold(t *testing.T) {
+	if got := shakeThreshold(255); got != 65536 {
+		t.Errorf("expected a guaranteed catch at 255, got %d", got)
+	}
+	if got := shakeThreshold(300); got != 65536 {
+		t.Errorf("expected a guaranteed catch above 255, got %d", got)
+	}
+
+	previous := 0
+	for _, a := range []float64{1, 10, 85, 200, 254} {
+		got := shakeThreshold(a)
+		if got <= previous || got >= 65536 {
+			t.Errorf("expected threshold for %v to grow and stay under 65536, got %d after %d", a, got, previous)
+		}
+		previous = got
+	}
+}
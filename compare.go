@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// compareRow is one row of the compare table. Rows with a better function
+// mark the best value of the row with a star.
+type compareRow struct {
+	Label  string
+	Values []string
+	Scores []int
+	better func(a, b int) bool
+}
+
+func higher(a, b int) bool { return a > b }
+
+func lower(a, b int) bool { return a < b }
+
+// marked returns the values of the row, with a star after every value
+// that ties for best. Rows where every value is the same have no best.
+func (row compareRow) marked() []string {
+	values := append([]string(nil), row.Values...)
+	if row.better == nil || len(row.Scores) == 0 {
+		return values
+	}
+	best := row.Scores[0]
+	allSame := true
+	for _, score := range row.Scores[1:] {
+		allSame = allSame && score == best
+		if row.better(score, best) {
+			best = score
+		}
+	}
+	if allSame {
+		return values
+	}
+	for i, score := range row.Scores {
+		if score == best {
+			values[i] += " *"
+		}
+	}
+	return values
+}
+
+func scoreRow(label string, scores []int, better func(a, b int) bool) compareRow {
+	row := compareRow{Label: label, Scores: scores, better: better}
+	for _, score := range scores {
+		row.Values = append(row.Values, fmt.Sprint(score))
+	}
+	return row
+}
+
+// weakness is an attack type that hits for more than normal damage.
+type weakness struct {
+	Type       string
+	Multiplier float64
+}
+
+// weaknesses returns the attack types that hit the given types for more
+// than normal damage.
+func weaknesses(chart typeChart, types []string) []weakness {
+	var weak []weakness
+	for _, attackType := range chart.attackTypes() {
+		if multiplier := chart.effectiveness(attackType, types); multiplier > 1 {
+			weak = append(weak, weakness{Type: attackType, Multiplier: multiplier})
+		}
+	}
+	return weak
+}
+
+// describeWeaknesses names the weaknesses, marking the ones that hit for
+// 4x.
+func describeWeaknesses(ctx context.Context, config *Config, weak []weakness) string {
+	names := make([]string, 0, len(weak))
+	for _, w := range weak {
+		name := localName(ctx, config, "type", w.Type)
+		if w.Multiplier >= 4 {
+			name += " 4x"
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func commandCompare(ctx context.Context, config *Config, params ...string) error {
+	if len(params) < 2 {
+		return errors.New("use compare <pokemon> <pokemon> [...]")
+	}
+	header, rows, err := compareTable(ctx, config, params)
+	if err != nil {
+		return err
+	}
+	printCompareTable(header, rows)
+	return nil
+}
+
+// compareTable builds the header and rows of the compare table, with a
+// column for each Pokemon.
+func compareTable(ctx context.Context, config *Config, params []string) (compareRow, []compareRow, error) {
+	chart, err := loadTypeChart(ctx, config)
+	if err != nil {
+		return compareRow{}, nil, err
+	}
+
+	header := compareRow{}
+	statScores := make([][]int, len(statNames))
+	var totals, weakCounts []int
+	typeRow := compareRow{Label: "types"}
+	abilityRow := compareRow{Label: "abilities"}
+	heightRow := compareRow{Label: "height"}
+	weightRow := compareRow{Label: "weight"}
+	weakRow := compareRow{Label: "weak to"}
+
+	for _, pokemon := range params {
+		data, err := fetchPokemon(ctx, config, resolveSpecies(ctx, config, pokemon))
+		if err != nil {
+			return compareRow{}, nil, err
+		}
+		types, err := typesOf(ctx, config, data)
+		if err != nil {
+			return compareRow{}, nil, err
+		}
+		header.Values = append(header.Values, localName(ctx, config, "pokemon-species", data.Name))
+
+		total := 0
+		for i, stat := range statNames {
+			statScores[i] = append(statScores[i], baseStat(data, stat))
+			total += baseStat(data, stat)
+		}
+		totals = append(totals, total)
+
+		typeRow.Values = append(typeRow.Values, strings.Join(localNames(ctx, config, "type", types), ", "))
+		var abilities []string
+		for _, slot := range data.Abilities {
+			name := localName(ctx, config, "ability", slot.Ability.Name)
+			if slot.IsHidden {
+				name += " (hidden)"
+			}
+			abilities = append(abilities, name)
+		}
+		abilityRow.Values = append(abilityRow.Values, strings.Join(abilities, ", "))
+		// Heights are in decimetres and weights in hectograms.
+		heightRow.Values = append(heightRow.Values, fmt.Sprintf("%.1f m", float64(data.Height)/10))
+		weightRow.Values = append(weightRow.Values, fmt.Sprintf("%.1f kg", float64(data.Weight)/10))
+
+		weak := weaknesses(chart, types)
+		weakRow.Values = append(weakRow.Values, describeWeaknesses(ctx, config, weak))
+		weakCounts = append(weakCounts, len(weak))
+	}
+
+	rows := make([]compareRow, 0, len(statNames)+7)
+	for i, stat := range statNames {
+		rows = append(rows, scoreRow(stat, statScores[i], higher))
+	}
+	rows = append(rows, scoreRow("total", totals, higher), typeRow, abilityRow, heightRow, weightRow,
+		weakRow, scoreRow("weaknesses", weakCounts, lower))
+	return header, rows, nil
+}
+
+// printCompareTable prints the rows in aligned columns, one per Pokemon.
+func printCompareTable(header compareRow, rows []compareRow) {
+	all := append([]compareRow{header}, rows...)
+	labelWidth := 0
+	widths := make([]int, len(header.Values))
+	cells := make([][]string, len(all))
+	for r, row := range all {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(row.Label))
+		cells[r] = row.marked()
+		for i, value := range cells[r] {
+			widths[i] = max(widths[i], utf8.RuneCountInString(value))
+		}
+	}
+	for r, row := range all {
+		line := fmt.Sprintf("%-*s", labelWidth, row.Label)
+		for i, value := range cells[r] {
+			line += fmt.Sprintf("  %-*s", widths[i], value)
+		}
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+}
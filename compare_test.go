@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestCompareRowMarked(t *testing.T) {
+	cases := []struct {
+		row      compareRow
+		expected []string
+	}{
+		{scoreRow("speed", []int{90, 56, 90}, higher), []string{"90 *", "56", "90 *"}},
+		{scoreRow("weaknesses", []int{1, 3}, lower), []string{"1 *", "3"}},
+		{scoreRow("hp", []int{40, 40}, higher), []string{"40", "40"}},
+		{compareRow{Label: "types", Values: []string{"electric", "normal"}}, []string{"electric", "normal"}},
+	}
+	for _, c := range cases {
+		if got := c.row.marked(); !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.row.Label, c.expected, got)
+		}
+	}
+}
+
+func TestWeaknesses(t *testing.T) {
+	chart := typeChart{
+		"electric": {"flying": 2, "water": 2, "ground": 0},
+		"ice":      {"flying": 2, "ground": 2},
+		"rock":     {"flying": 2, "ground": 0.5},
+	}
+	got := weaknesses(chart, []string{"water", "flying"})
+	expected := []weakness{{"electric", 4}, {"ice", 2}, {"rock", 2}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestDescribeWeaknesses(t *testing.T) {
+	config := newTestConfig(t, fakeAPI{
+		"/type/electric/": namedEndpoint{Name: "electric", Names: []Name{
+			{Name: "Électrik", Language: NamedAPIResource{Name: "fr"}},
+		}},
+		"/type/ice/": namedEndpoint{Name: "ice", Names: []Name{
+			{Name: "Glace", Language: NamedAPIResource{Name: "fr"}},
+		}},
+	})
+	config.Language = "fr"
+
+	got := describeWeaknesses(context.Background(), config, []weakness{{"electric", 4}, {"ice", 2}})
+	if expected := "Électrik 4x, Glace"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	config := newBattleConfig(t)
+	ctx := context.Background()
+
+	if err := commandCompare(ctx, config, "pikachu"); err == nil {
+		t.Error("expected an error comparing a single pokemon")
+	}
+	if err := commandCompare(ctx, config, "pikachu", "pidgey"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	header, rows, err := compareTable(ctx, config, []string{"pikachu", "pidgey"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"pikachu", "pidgey"}; !reflect.DeepEqual(header.Values, expected) {
+		t.Errorf("expected header %v, got %v", expected, header.Values)
+	}
+	expected := map[string][]string{
+		"speed":      {"90 *", "56"},
+		"total":      {"320 *", "251"},
+		"types":      {"electric", "normal, flying"},
+		"weak to":    {"", "electric"},
+		"weaknesses": {"0 *", "1"},
+	}
+	for _, row := range rows {
+		if values, ok := expected[row.Label]; ok {
+			if got := row.marked(); !reflect.DeepEqual(got, values) {
+				t.Errorf("%s: expected %q, got %q", row.Label, values, got)
+			}
+			delete(expected, row.Label)
+		}
+	}
+	for label := range expected {
+		t.Errorf("missing row %s", label)
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type PokedexEndpoint struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	Names          []Name `json:"names"`
+	IsMainSeries   bool   `json:"is_main_series"`
+	PokemonEntries []struct {
+		EntryNumber    int              `json:"entry_number"`
+		PokemonSpecies NamedAPIResource `json:"pokemon_species"`
+	} `json:"pokemon_entries"`
+}
+
+type GenerationEndpoint struct {
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	Names          []Name             `json:"names"`
+	PokemonSpecies []NamedAPIResource `json:"pokemon_species"`
+}
+
+// dexEntry is a species in a Pokedex, with its number in that Pokedex.
+type dexEntry struct {
+	Number  int
+	Species string
+}
+
+// markSeen records species the trainer has come across, even without
+// catching them.
+func markSeen(config *Config, species ...string) {
+	if config.Seen == nil {
+		config.Seen = make(map[string]bool)
+	}
+	for _, name := range species {
+		config.Seen[name] = true
+	}
+}
+
+// caughtSpecies returns every species the trainer has caught, including
+// the ones they evolved into.
+func caughtSpecies(config *Config) map[string]bool {
+	caught := make(map[string]bool)
+	for name, data := range *config.Pokedex {
+		caught[name] = true
+		if data.Species.Name != "" {
+			caught[data.Species.Name] = true
+		}
+	}
+	return caught
+}
+
+// fetchDexEntries returns the species of a regional Pokedex, such as kanto,
+// or of a generation, such as generation-i, in Pokedex order.
+func fetchDexEntries(ctx context.Context, config *Config, dex string) ([]dexEntry, error) {
+	if generationNumber(dex) > 0 {
+		data := GenerationEndpoint{}
+		err := fetchJSON(ctx, config, fmt.Sprintf("%s/generation/%s/", config.BaseURL, dex), &data)
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]dexEntry, 0, len(data.PokemonSpecies))
+		for _, species := range data.PokemonSpecies {
+			// Generations list species unordered, so number them by their
+			// national dex number, the ID at the end of their URL.
+			number, _ := strconv.Atoi(path.Base(species.URL))
+			entries = append(entries, dexEntry{Number: number, Species: species.Name})
+		}
+		sortDexEntries(entries)
+		return entries, nil
+	}
+
+	data := PokedexEndpoint{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/pokedex/%s/", config.BaseURL, dex), &data)
+	if err != nil {
+		return nil, fmt.Errorf("unknown pokedex %s: %w", dex, err)
+	}
+	entries := make([]dexEntry, 0, len(data.PokemonEntries))
+	for _, entry := range data.PokemonEntries {
+		entries = append(entries, dexEntry{Number: entry.EntryNumber, Species: entry.PokemonSpecies.Name})
+	}
+	return entries, nil
+}
+
+func sortDexEntries(entries []dexEntry) {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Number < entries[j].Number })
+}
+
+// completionDexes returns the Pokedexes to show completion for: the
+// national dex and those of the selected game version, or every regional
+// dex of the main series games without a version.
+func completionDexes(ctx context.Context, config *Config) ([]string, error) {
+	dexes := []string{"national"}
+	versionGroup, err := currentVersionGroup(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if versionGroup == "" {
+		return mainSeriesDexes(ctx, config)
+	}
+	data := VersionGroupEndpoint{}
+	err = fetchJSON(ctx, config, fmt.Sprintf("%s/version-group/%s/", config.BaseURL, versionGroup), &data)
+	if err != nil {
+		return nil, err
+	}
+	for _, dex := range data.Pokedexes {
+		if dex.Name != "national" {
+			dexes = append(dexes, dex.Name)
+		}
+	}
+	return dexes, nil
+}
+
+// mainSeriesDexes returns the national dex followed by the regional
+// Pokedexes of the main series games, leaving out the ones of spin-offs.
+func mainSeriesDexes(ctx context.Context, config *Config) ([]string, error) {
+	list := NamedAPIResourceList{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/pokedex/?limit=100", config.BaseURL), &list)
+	if err != nil {
+		return nil, err
+	}
+	dexes := []string{"national"}
+	for _, dex := range list.Results {
+		if dex.Name == "national" {
+			continue
+		}
+		data := PokedexEndpoint{}
+		err := fetchJSON(ctx, config, fmt.Sprintf("%s/pokedex/%s/", config.BaseURL, dex.Name), &data)
+		if err != nil {
+			return nil, err
+		}
+		if data.IsMainSeries {
+			dexes = append(dexes, dex.Name)
+		}
+	}
+	return dexes, nil
+}
+
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
+
+func commandCompletion(ctx context.Context, config *Config, params ...string) error {
+	dexes := []string{}
+	if len(params) > 0 {
+		dexes = append(dexes, strings.ToLower(params[0]))
+	} else {
+		var err error
+		dexes, err = completionDexes(ctx, config)
+		if err != nil {
+			return err
+		}
+	}
+
+	caught := caughtSpecies(config)
+	for _, dex := range dexes {
+		entries, err := fetchDexEntries(ctx, config, dex)
+		if err != nil {
+			return err
+		}
+		var missing []dexEntry
+		seenCount := 0
+		for _, entry := range entries {
+			if caught[entry.Species] || config.Seen[entry.Species] {
+				seenCount++
+			}
+			if !caught[entry.Species] {
+				missing = append(missing, entry)
+			}
+		}
+		caughtCount := len(entries) - len(missing)
+		fmt.Printf("%s: caught %d/%d (%.1f%%), seen %d/%d (%.1f%%)\n", dex,
+			caughtCount, len(entries), percent(caughtCount, len(entries)),
+			seenCount, len(entries), percent(seenCount, len(entries)))
+
+		// Only a single Pokedex asked for by name lists what is missing, as
+		// the national dex alone has over a thousand entries.
+		if len(params) == 0 || len(missing) == 0 {
+			continue
+		}
+		fmt.Println("Missing:")
+		for _, entry := range missing {
+			seen := ""
+			if config.Seen[entry.Species] {
+				seen = " (seen)"
+			}
+			fmt.Printf("  #%03d %s%s\n", entry.Number, localName(ctx, config, "pokemon-species", entry.Species), seen)
+		}
+	}
+	return nil
+}
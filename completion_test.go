@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFetchDexEntries(t *testing.T) {
+	kanto := PokedexEndpoint{Name: "kanto"}
+	for i, species := range []string{"bulbasaur", "ivysaur"} {
+		kanto.PokemonEntries = append(kanto.PokemonEntries, struct {
+			EntryNumber    int              `json:"entry_number"`
+			PokemonSpecies NamedAPIResource `json:"pokemon_species"`
+		}{EntryNumber: i + 1, PokemonSpecies: NamedAPIResource{Name: species}})
+	}
+	config := newTestConfig(t, fakeAPI{
+		"/pokedex/kanto/": kanto,
+		"/generation/generation-i/": GenerationEndpoint{Name: "generation-i", PokemonSpecies: []NamedAPIResource{
+			{Name: "pikachu", URL: "BASE/pokemon-species/25/"},
+			{Name: "bulbasaur", URL: "BASE/pokemon-species/1/"},
+		}},
+	})
+	ctx := context.Background()
+
+	cases := []struct {
+		dex      string
+		expected []dexEntry
+	}{
+		{"kanto", []dexEntry{{1, "bulbasaur"}, {2, "ivysaur"}}},
+		{"generation-i", []dexEntry{{1, "bulbasaur"}, {25, "pikachu"}}},
+	}
+	for _, c := range cases {
+		got, err := fetchDexEntries(ctx, config, c.dex)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.dex, err)
+		}
+		if !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.dex, c.expected, got)
+		}
+	}
+	if _, err := fetchDexEntries(ctx, config, "johto"); err == nil {
+		t.Error("expected an error for an unknown pokedex")
+	}
+}
+
+func TestCompletionDexes(t *testing.T) {
+	config := newTestConfig(t, fakeAPI{
+		"/pokedex/": NamedAPIResourceList{Results: []NamedAPIResource{
+			{Name: "national"}, {Name: "kanto"}, {Name: "conquest-gallery"}, {Name: "original-johto"},
+		}},
+		"/pokedex/kanto/":            PokedexEndpoint{Name: "kanto", IsMainSeries: true},
+		"/pokedex/conquest-gallery/": PokedexEndpoint{Name: "conquest-gallery"},
+		"/pokedex/original-johto/":   PokedexEndpoint{Name: "original-johto", IsMainSeries: true},
+	})
+
+	got, err := completionDexes(context.Background(), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"national", "kanto", "original-johto"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v without a version, got %v", expected, got)
+	}
+}
+
+func TestSeenTracking(t *testing.T) {
+	config := newTestConfig(t, fakeAPI{
+		"/location-area/viridian-forest-area/": json.RawMessage(forestAreaJSON),
+	})
+	config.Version = "red"
+	ctx := context.Background()
+
+	if err := exploreArea(ctx, config, "viridian-forest-area"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !config.Seen["caterpie"] {
+		t.Error("expected explored pokemon to be seen")
+	}
+	if config.Seen["pikachu"] {
+		t.Error("expected pokemon not in the selected version to stay unseen")
+	}
+	if caughtSpecies(config)["caterpie"] {
+		t.Error("expected a seen pokemon not to count as caught")
+	}
+}
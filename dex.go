@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func commandDex(ctx context.Context, config *Config, params ...string) error {
+	if len(params) == 0 {
+		return errors.New("missing pokemon name")
+	}
+	data, err := fetchPokemon(ctx, config, resolveSpecies(ctx, config, params[0]))
+	if err != nil {
+		return err
+	}
+	species, err := fetchSpecies(ctx, config, data)
+	if err != nil {
+		return err
+	}
+
+	language := displayLanguage(config)
+	fmt.Printf("#%03d %s, the %s\n", species.ID, pickName(config, "pokemon-species", species.Name, species.Names),
+		species.genus(language))
+	if text := species.flavorText(config, language); text != "" {
+		fmt.Println(text)
+	}
+	fmt.Println("Generation:", species.Generation.Name)
+	fmt.Println("Habitat:", nameOrUnknown(species.Habitat))
+	fmt.Println("Color:", species.Color.Name)
+	fmt.Println("Shape:", nameOrUnknown(species.Shape))
+	eggGroups := make([]string, 0, len(species.EggGroups))
+	for _, group := range species.EggGroups {
+		eggGroups = append(eggGroups, group.Name)
+	}
+	fmt.Println("Egg groups:", strings.Join(eggGroups, ", "))
+	if species.IsLegendary {
+		fmt.Println("Legendary pokemon")
+	}
+	if species.IsMythical {
+		fmt.Println("Mythical pokemon")
+	}
+	return nil
+}
+
+func nameOrUnknown(resource *NamedAPIResource) string {
+	if resource == nil {
+		return "unknown"
+	}
+	return resource.Name
+}
+
+func (species PokemonSpeciesEndpoint) genus(language string) string {
+	for _, genus := range species.Genera {
+		if genus.Language.Name == language {
+			return genus.Genus
+		}
+	}
+	if language != defaultLanguage {
+		return species.genus(defaultLanguage)
+	}
+	return "unknown pokemon"
+}
+
+// flavorText returns the Pokedex entry for the selected game version, or
+// the most recent entry when the version has none. Species without an
+// entry in the language get the English one.
+func (species PokemonSpeciesEndpoint) flavorText(config *Config, language string) string {
+	text := ""
+	for _, entry := range species.FlavorTextEntries {
+		if entry.Language.Name != language {
+			continue
+		}
+		text = entry.FlavorText
+		if config.Version != "" && entry.Version.Name == config.Version {
+			break
+		}
+	}
+	if text == "" && language != defaultLanguage {
+		return species.flavorText(config, defaultLanguage)
+	}
+	// Entries are wrapped with newlines and form feeds as on the game screen.
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlavorText(t *testing.T) {
+	species := PokemonSpeciesEndpoint{}
+	err := json.Unmarshal([]byte(`{"flavor_text_entries": [
+		{"flavor_text": "When several of\nthese POKéMON\fgather...", "language": {"name": "en"}, "version": {"name": "red"}},
+		{"flavor_text": "Lorsque plusieurs...", "language": {"name": "fr"}, "version": {"name": "x"}},
+		{"flavor_text": "It keeps its tail\nraised to monitor\fits surroundings.", "language": {"name": "en"}, "version": {"name": "yellow"}}
+	]}`), &species)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		version  string
+		expected string
+	}{
+		{"red", "When several of these POKéMON gather..."},
+		{"yellow", "It keeps its tail raised to monitor its surroundings."},
+		{"", "It keeps its tail raised to monitor its surroundings."},
+		{"platinum", "It keeps its tail raised to monitor its surroundings."},
+	}
+	for _, c := range cases {
+		config := &Config{Version: c.version}
+		if got := species.flavorText(config, "en"); got != c.expected {
+			t.Errorf("version %q: expected %q, got %q", c.version, c.expected, got)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WildPokemon is a Pokemon met in the wild that can be caught.
+type WildPokemon struct {
+	Name   string
+	Level  int
+	HP     int
+	MaxHP  int
+	Status string
+	Method string
+	Area   string
+}
+
+// encounterSlot is a single way of meeting a Pokemon in an area, weighted
+// by its chance.
+type encounterSlot struct {
+	Pokemon  string
+	Method   string
+	Chance   int
+	MinLevel int
+	MaxLevel int
+}
+
+func commandEncounter(ctx context.Context, config *Config, params ...string) error {
+	if config.Battle != nil {
+		return errInBattle
+	}
+	area, err := resolveArea(config, nil)
+	if err != nil {
+		return err
+	}
+	data, err := fetchArea(ctx, config, area)
+	if err != nil {
+		return err
+	}
+
+	method := ""
+	if len(params) > 0 {
+		method = params[0]
+	}
+	now := time.Now()
+	slots := encounterSlots(data, config.Version, method, now)
+	if len(slots) == 0 {
+		methods := encounterMethods(encounterSlots(data, config.Version, "", now))
+		if len(methods) == 0 {
+			return fmt.Errorf("there are no wild pokemon in %s", area)
+		}
+		return fmt.Errorf("no encounters by %s in %s, try one of: %s", method, area, strings.Join(methods, ", "))
+	}
+
+	slot := rollEncounter(config.Rand, slots)
+	pokemon, err := fetchPokemon(ctx, config, slot.Pokemon)
+	if err != nil {
+		return err
+	}
+	level := slot.MinLevel + config.Rand.Intn(slot.MaxLevel-slot.MinLevel+1)
+	maxHP := wildMaxHP(baseStat(pokemon, "hp"), level)
+	config.Wild = &WildPokemon{
+		Name:   slot.Pokemon,
+		Level:  level,
+		HP:     maxHP,
+		MaxHP:  maxHP,
+		Method: slot.Method,
+		Area:   area,
+	}
+	markSeen(config, slot.Pokemon)
+	fmt.Printf("A wild %s (Lv. %d) appeared!\n", localName(ctx, config, "pokemon-species", config.Wild.Name), config.Wild.Level)
+	return nil
+}
+
+// encounterSlots lists every encounter of the area that can happen at now,
+// restricted to version and method unless they are empty.
+func encounterSlots(data LocationAreaEndpoint, version, method string, now time.Time) []encounterSlot {
+	var slots []encounterSlot
+	for _, encounter := range data.PokemonEncounters {
+		for _, details := range encounter.VersionDetails {
+			if version != "" && details.Version.Name != version {
+				continue
+			}
+			for _, detail := range details.EncounterDetails {
+				if detail.Chance <= 0 || (method != "" && detail.Method.Name != method) {
+					continue
+				}
+				if !conditionsMet(detail.ConditionValues, now) {
+					continue
+				}
+				slots = append(slots, encounterSlot{
+					Pokemon:  encounter.Pokemon.Name,
+					Method:   detail.Method.Name,
+					Chance:   detail.Chance,
+					MinLevel: detail.MinLevel,
+					MaxLevel: max(detail.MaxLevel, detail.MinLevel),
+				})
+			}
+		}
+	}
+	return slots
+}
+
+// conditionsMet reports whether every condition of an encounter, such as
+// time-night or swarm-yes, holds at now. The time of day and the season
+// follow the clock, while swarms, the Poke Radar, a GBA game in the slot,
+// the radio and the like are taken to be off.
+func conditionsMet(conditions []NamedAPIResource, now time.Time) bool {
+	for _, condition := range conditions {
+		name := condition.Name
+		switch {
+		case strings.HasPrefix(name, "time-"):
+			if name != "time-"+encounterTime(now) {
+				return false
+			}
+		case strings.HasPrefix(name, "season-"):
+			if name != "season-"+encounterSeason(now) {
+				return false
+			}
+		case !strings.HasSuffix(name, "-no") && !strings.HasSuffix(name, "-off") && !strings.HasSuffix(name, "-none"):
+			return false
+		}
+	}
+	return true
+}
+
+// encounterTime returns the time of day wild Pokemon follow: morning from
+// 4 to 10, day until 20 and night after that.
+func encounterTime(now time.Time) string {
+	switch hour := now.Hour(); {
+	case hour >= 4 && hour < 10:
+		return "morning"
+	case hour >= 10 && hour < 20:
+		return "day"
+	default:
+		return "night"
+	}
+}
+
+// encounterSeason returns the season of generation V, which changes every
+// month starting with spring in January.
+func encounterSeason(now time.Time) string {
+	seasons := []string{"spring", "summer", "autumn", "winter"}
+	return seasons[(int(now.Month())-1)%len(seasons)]
+}
+
+func encounterMethods(slots []encounterSlot) []string {
+	seen := make(map[string]bool)
+	var methods []string
+	for _, slot := range slots {
+		if !seen[slot.Method] {
+			seen[slot.Method] = true
+			methods = append(methods, slot.Method)
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// rollEncounter picks one of slots with probability proportional to its
+// chance. slots must not be empty.
+func rollEncounter(rng *rand.Rand, slots []encounterSlot) encounterSlot {
+	total := 0
+	for _, slot := range slots {
+		total += slot.Chance
+	}
+	roll := rng.Intn(total)
+	for _, slot := range slots {
+		if roll < slot.Chance {
+			return slot
+		}
+		roll -= slot.Chance
+	}
+	return slots[len(slots)-1]
+}
+
+func baseStat(pokemon PokemonEndpoint, stat string) int {
+	for _, s := range pokemon.Stats {
+		if s.Stat.Name == stat {
+			return s.BaseStat
+		}
+	}
+	return 0
+}
+
+// wildMaxHP returns the maximum HP of a wild Pokemon of the given base HP
+// and level.
+func wildMaxHP(base, level int) int {
+	return 2*base*level/100 + level + 10
+}
+
+// wildInArea reports whether pokemon is the wild Pokemon currently
+// encountered in the trainer's area.
+func wildInArea(config *Config, pokemon string) bool {
+	return config.Wild != nil && config.Wild.Name == pokemon && config.Wild.Area == config.CurrentArea
+}
+
+var errNoWildPokemon = errors.New("there is no wild pokemon here, use encounter first")
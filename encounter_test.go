@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const forestAreaJSON = `{
+	"name": "viridian-forest-area",
+	"pokemon_encounters": [
+		{
+			"pokemon": {"name": "caterpie"},
+			"version_details": [{
+				"version": {"name": "red"},
+				"max_chance": 50,
+				"encounter_details": [
+					{"chance": 40, "min_level": 3, "max_level": 5, "method": {"name": "walk"}},
+					{"chance": 10, "min_level": 4, "max_level": 4, "method": {"name": "headbutt"}}
+				]
+			}]
+		},
+		{
+			"pokemon": {"name": "pikachu"},
+			"version_details": [{
+				"version": {"name": "yellow"},
+				"max_chance": 5,
+				"encounter_details": [
+					{"chance": 5, "min_level": 3, "max_level": 5, "method": {"name": "walk"}}
+				]
+			}]
+		}
+	]
+}`
+
+func decodeForestArea(t *testing.T) LocationAreaEndpoint {
+	t.Helper()
+	data := LocationAreaEndpoint{}
+	if err := json.Unmarshal([]byte(forestAreaJSON), &data); err != nil {
+		t.Fatalf("decoding area: %v", err)
+	}
+	return data
+}
+
+func TestEncounterSlots(t *testing.T) {
+	data := decodeForestArea(t)
+
+	cases := []struct {
+		version  string
+		method   string
+		expected []encounterSlot
+	}{
+		{
+			method: "headbutt",
+			expected: []encounterSlot{
+				{Pokemon: "caterpie", Method: "headbutt", Chance: 10, MinLevel: 4, MaxLevel: 4},
+			},
+		},
+		{
+			method: "walk",
+			expected: []encounterSlot{
+				{Pokemon: "caterpie", Method: "walk", Chance: 40, MinLevel: 3, MaxLevel: 5},
+				{Pokemon: "pikachu", Method: "walk", Chance: 5, MinLevel: 3, MaxLevel: 5},
+			},
+		},
+		{
+			version: "yellow",
+			method:  "walk",
+			expected: []encounterSlot{
+				{Pokemon: "pikachu", Method: "walk", Chance: 5, MinLevel: 3, MaxLevel: 5},
+			},
+		},
+		{
+			version:  "red",
+			method:   "surf",
+			expected: nil,
+		},
+	}
+	for _, c := range cases {
+		slots := encounterSlots(data, c.version, c.method, time.Now())
+		if !reflect.DeepEqual(slots, c.expected) {
+			t.Errorf("version %q, method %q: expected %v, got %v", c.version, c.method, c.expected, slots)
+		}
+	}
+
+	methods := encounterMethods(encounterSlots(data, "", "", time.Now()))
+	if !reflect.DeepEqual(methods, []string{"headbutt", "walk"}) {
+		t.Errorf("expected methods headbutt and walk, got %v", methods)
+	}
+}
+
+func TestEncounterSlotsConditions(t *testing.T) {
+	data := LocationAreaEndpoint{}
+	err := json.Unmarshal([]byte(`{
+		"name": "sinnoh-route-201-area",
+		"pokemon_encounters": [
+			{
+				"pokemon": {"name": "starly"},
+				"version_details": [{
+					"version": {"name": "diamond"},
+					"encounter_details": [
+						{"chance": 50, "min_level": 2, "max_level": 3, "method": {"name": "walk"}}
+					]
+				}]
+			},
+			{
+				"pokemon": {"name": "kricketot"},
+				"version_details": [{
+					"version": {"name": "diamond"},
+					"encounter_details": [
+						{"chance": 10, "min_level": 3, "max_level": 3, "method": {"name": "walk"},
+							"condition_values": [{"name": "time-night"}]}
+					]
+				}]
+			},
+			{
+				"pokemon": {"name": "doduo"},
+				"version_details": [{
+					"version": {"name": "diamond"},
+					"encounter_details": [
+						{"chance": 20, "min_level": 3, "max_level": 3, "method": {"name": "walk"},
+							"condition_values": [{"name": "swarm-yes"}]},
+						{"chance": 5, "min_level": 3, "max_level": 3, "method": {"name": "walk"},
+							"condition_values": [{"name": "swarm-no"}, {"name": "radar-off"}]}
+					]
+				}]
+			}
+		]
+	}`), &data)
+	if err != nil {
+		t.Fatalf("decoding area: %v", err)
+	}
+
+	cases := []struct {
+		hour     int
+		expected []string
+	}{
+		{hour: 12, expected: []string{"starly 50", "doduo 5"}},
+		{hour: 22, expected: []string{"starly 50", "kricketot 10", "doduo 5"}},
+	}
+	for _, c := range cases {
+		now := time.Date(2024, time.June, 1, c.hour, 0, 0, 0, time.UTC)
+		var got []string
+		for _, slot := range encounterSlots(data, "diamond", "walk", now) {
+			got = append(got, fmt.Sprintf("%s %d", slot.Pokemon, slot.Chance))
+		}
+		if !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("at %d:00: expected %v, got %v", c.hour, c.expected, got)
+		}
+	}
+}
+
+func TestRollEncounterSingleSlot(t *testing.T) {
+	slots := []encounterSlot{{Pokemon: "caterpie", Chance: 0}, {Pokemon: "pikachu", Chance: 5}}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		if slot := rollEncounter(rng, slots); slot.Pokemon != "pikachu" {
+			t.Fatalf("expected only pikachu to be rolled, got %s", slot.Pokemon)
+		}
+	}
+}
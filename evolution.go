@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxFriendship caps how friendly a Pokemon can get with its trainer.
+const maxFriendship = 255
+
+type EvolutionDetail struct {
+	Trigger               NamedAPIResource  `json:"trigger"`
+	Item                  *NamedAPIResource `json:"item"`
+	HeldItem              *NamedAPIResource `json:"held_item"`
+	KnownMove             *NamedAPIResource `json:"known_move"`
+	KnownMoveType         *NamedAPIResource `json:"known_move_type"`
+	Location              *NamedAPIResource `json:"location"`
+	PartySpecies          *NamedAPIResource `json:"party_species"`
+	PartyType             *NamedAPIResource `json:"party_type"`
+	TradeSpecies          *NamedAPIResource `json:"trade_species"`
+	Gender                *int              `json:"gender"`
+	MinLevel              int               `json:"min_level"`
+	MinHappiness          int               `json:"min_happiness"`
+	MinAffection          int               `json:"min_affection"`
+	MinBeauty             int               `json:"min_beauty"`
+	RelativePhysicalStats *int              `json:"relative_physical_stats"`
+	TimeOfDay             string            `json:"time_of_day"`
+	NeedsOverworldRain    bool              `json:"needs_overworld_rain"`
+	TurnUpsideDown        bool              `json:"turn_upside_down"`
+}
+
+type ChainLink struct {
+	IsBaby           bool              `json:"is_baby"`
+	Species          NamedAPIResource  `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+type EvolutionChainEndpoint struct {
+	ID    int       `json:"id"`
+	Chain ChainLink `json:"chain"`
+}
+
+func fetchEvolutionChain(ctx context.Context, config *Config, species PokemonSpeciesEndpoint) (EvolutionChainEndpoint, error) {
+	data := EvolutionChainEndpoint{Chain: ChainLink{Species: NamedAPIResource{Name: species.Name}}}
+	if species.EvolutionChain.URL == "" {
+		return data, nil
+	}
+	err := fetchJSON(ctx, config, species.EvolutionChain.URL, &data)
+	return data, err
+}
+
+// findLink returns the link of species in the chain, or nil.
+func findLink(link *ChainLink, species string) *ChainLink {
+	if link.Species.Name == species {
+		return link
+	}
+	for i := range link.EvolvesTo {
+		if found := findLink(&link.EvolvesTo[i], species); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// describeEvolution turns the conditions of an evolution into text such as
+// "level-up, level 16" or "use-item, fire-stone".
+func describeEvolution(detail EvolutionDetail) string {
+	parts := []string{detail.Trigger.Name}
+	if detail.MinLevel > 0 {
+		parts = append(parts, fmt.Sprintf("level %d", detail.MinLevel))
+	}
+	if detail.Item != nil {
+		parts = append(parts, detail.Item.Name)
+	}
+	if detail.HeldItem != nil {
+		parts = append(parts, "holding "+detail.HeldItem.Name)
+	}
+	if detail.MinHappiness > 0 {
+		parts = append(parts, fmt.Sprintf("friendship %d", detail.MinHappiness))
+	}
+	if detail.MinAffection > 0 {
+		parts = append(parts, fmt.Sprintf("affection %d", detail.MinAffection))
+	}
+	if detail.MinBeauty > 0 {
+		parts = append(parts, fmt.Sprintf("beauty %d", detail.MinBeauty))
+	}
+	if detail.KnownMove != nil {
+		parts = append(parts, "knowing "+detail.KnownMove.Name)
+	}
+	if detail.KnownMoveType != nil {
+		parts = append(parts, "knowing a "+detail.KnownMoveType.Name+" move")
+	}
+	if detail.Location != nil {
+		parts = append(parts, "at "+detail.Location.Name)
+	}
+	if detail.TimeOfDay != "" {
+		parts = append(parts, "during the "+detail.TimeOfDay)
+	}
+	if detail.Gender != nil {
+		parts = append(parts, genderName(*detail.Gender)+" only")
+	}
+	if detail.TradeSpecies != nil {
+		parts = append(parts, "for "+detail.TradeSpecies.Name)
+	}
+	if detail.PartySpecies != nil {
+		parts = append(parts, "with "+detail.PartySpecies.Name+" in the party")
+	}
+	if detail.PartyType != nil {
+		parts = append(parts, "with a "+detail.PartyType.Name+" pokemon in the party")
+	}
+	if detail.NeedsOverworldRain {
+		parts = append(parts, "while raining")
+	}
+	if detail.TurnUpsideDown {
+		parts = append(parts, "upside down")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// genderName turns a PokeAPI gender ID into the gender OwnedPokemon uses.
+func genderName(gender int) string {
+	if gender == 1 {
+		return "female"
+	}
+	return "male"
+}
+
+func commandEvolutions(ctx context.Context, config *Config, params ...string) error {
+	if len(params) == 0 {
+		return errors.New("missing pokemon name")
+	}
+	data, err := fetchPokemon(ctx, config, resolveSpecies(ctx, config, params[0]))
+	if err != nil {
+		return err
+	}
+	species, err := fetchSpecies(ctx, config, data)
+	if err != nil {
+		return err
+	}
+	chain, err := fetchEvolutionChain(ctx, config, species)
+	if err != nil {
+		return err
+	}
+	printChain(ctx, config, chain.Chain, 0)
+	return nil
+}
+
+func printChain(ctx context.Context, config *Config, link ChainLink, depth int) {
+	indent := strings.Repeat("  ", depth)
+	name := localName(ctx, config, "pokemon-species", link.Species.Name)
+	if depth == 0 {
+		fmt.Println(name)
+	} else {
+		conditions := make([]string, 0, len(link.EvolutionDetails))
+		for _, detail := range link.EvolutionDetails {
+			conditions = append(conditions, describeEvolution(detail))
+		}
+		fmt.Printf("%s-> %s (%s)\n", indent, name, strings.Join(conditions, " or "))
+	}
+	for _, next := range link.EvolvesTo {
+		printChain(ctx, config, next, depth+1)
+	}
+}
+
+// timeOfDay returns the in-game time of day, day or night, with the hour
+// before nightfall also counting as dusk.
+func timeOfDay(now time.Time) []string {
+	hour := now.Hour()
+	switch {
+	case hour == 17:
+		return []string{"day", "dusk"}
+	case hour >= 6 && hour < 18:
+		return []string{"day"}
+	default:
+		return []string{"night"}
+	}
+}
+
+// canEvolve reports whether owned meets every condition of an evolution
+// for the trigger, such as level-up or use-item with item. Conditions the
+// game does not model, like trading or beauty, are never met.
+func canEvolve(detail EvolutionDetail, owned *OwnedPokemon, trigger, item string, now time.Time) bool {
+	if detail.Trigger.Name != trigger {
+		return false
+	}
+	if detail.HeldItem != nil || detail.KnownMoveType != nil || detail.Location != nil ||
+		detail.PartySpecies != nil || detail.PartyType != nil || detail.TradeSpecies != nil ||
+		detail.RelativePhysicalStats != nil || detail.MinAffection > 0 || detail.MinBeauty > 0 ||
+		detail.NeedsOverworldRain || detail.TurnUpsideDown {
+		return false
+	}
+	if detail.Item != nil && detail.Item.Name != item {
+		return false
+	}
+	if owned.Level < detail.MinLevel || owned.Friendship < detail.MinHappiness {
+		return false
+	}
+	if detail.Gender != nil && genderName(*detail.Gender) != owned.Gender {
+		return false
+	}
+	if detail.KnownMove != nil && !containsString(owned.Moves, detail.KnownMove.Name) {
+		return false
+	}
+	if detail.TimeOfDay != "" && !containsString(timeOfDay(now), detail.TimeOfDay) {
+		return false
+	}
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// tryEvolve evolves owned if one of its evolutions is triggered and
+// reports whether it did.
+func tryEvolve(ctx context.Context, config *Config, owned *OwnedPokemon, trigger, item string) (bool, error) {
+	data, err := fetchPokemon(ctx, config, owned.Species)
+	if err != nil {
+		return false, err
+	}
+	species, err := fetchSpecies(ctx, config, data)
+	if err != nil {
+		return false, err
+	}
+	chain, err := fetchEvolutionChain(ctx, config, species)
+	if err != nil {
+		return false, err
+	}
+	link := findLink(&chain.Chain, species.Name)
+	if link == nil {
+		return false, nil
+	}
+
+	for _, next := range link.EvolvesTo {
+		for _, detail := range next.EvolutionDetails {
+			if canEvolve(detail, owned, trigger, item, time.Now()) {
+				return true, evolve(ctx, config, owned, next.Species.Name)
+			}
+		}
+	}
+	return false, nil
+}
+
+func evolve(ctx context.Context, config *Config, owned *OwnedPokemon, species string) error {
+	evolved, err := fetchPokemon(ctx, config, species)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("What? %s is evolving!\n", ownedName(ctx, config, owned))
+	fmt.Printf("Congratulations! Your %s evolved into %s!\n", localName(ctx, config, "pokemon-species", owned.Species),
+		localName(ctx, config, "pokemon-species", evolved.Name))
+	owned.Species = evolved.Name
+	(*config.Pokedex)[evolved.Name] = evolved
+	return nil
+}
+
+// useEvolutionItem uses an evolution item such as a fire-stone on one of
+// the trainer's Pokemon.
+func useEvolutionItem(ctx context.Context, config *Config, item string, params []string) error {
+	if config.Battle != nil {
+		return errInBattle
+	}
+	if len(params) == 0 {
+		return fmt.Errorf("use %s <pokemon>", item)
+	}
+	owned, err := findOwned(config, params[0])
+	if err != nil {
+		return err
+	}
+	evolved, err := tryEvolve(ctx, config, owned, "use-item", item)
+	if err != nil {
+		return err
+	}
+	if !evolved {
+		return fmt.Errorf("%s has no effect on %s", localName(ctx, config, "item", item), ownedName(ctx, config, owned))
+	}
+	return takeItem(config, item)
+}
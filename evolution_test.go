@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCanEvolve(t *testing.T) {
+	owned := &OwnedPokemon{Level: 20, Friendship: 70, Gender: "female", Moves: []string{"tackle"}}
+	noon := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	female := 1
+
+	cases := []struct {
+		name   string
+		detail EvolutionDetail
+		item   string
+		want   bool
+	}{
+		{"level reached", EvolutionDetail{Trigger: NamedAPIResource{Name: "level-up"}, MinLevel: 16}, "", true},
+		{"level too low", EvolutionDetail{Trigger: NamedAPIResource{Name: "level-up"}, MinLevel: 36}, "", false},
+		{"friendship too low", EvolutionDetail{Trigger: NamedAPIResource{Name: "level-up"}, MinHappiness: 220}, "", false},
+		{"wrong time of day", EvolutionDetail{Trigger: NamedAPIResource{Name: "level-up"}, TimeOfDay: "night"}, "", false},
+		{"gender", EvolutionDetail{Trigger: NamedAPIResource{Name: "level-up"}, Gender: &female}, "", true},
+		{"known move", EvolutionDetail{Trigger: NamedAPIResource{Name: "level-up"}, KnownMove: &NamedAPIResource{Name: "tackle"}}, "", true},
+		{"right item", EvolutionDetail{Trigger: NamedAPIResource{Name: "use-item"}, Item: &NamedAPIResource{Name: "fire-stone"}}, "fire-stone", true},
+		{"wrong item", EvolutionDetail{Trigger: NamedAPIResource{Name: "use-item"}, Item: &NamedAPIResource{Name: "fire-stone"}}, "water-stone", false},
+		{"trade", EvolutionDetail{Trigger: NamedAPIResource{Name: "trade"}}, "", false},
+	}
+	for _, c := range cases {
+		trigger := "level-up"
+		if c.item != "" {
+			trigger = "use-item"
+		}
+		if got := canEvolve(c.detail, owned, trigger, c.item, noon); got != c.want {
+			t.Errorf("%s: expected %v, got %v", c.name, c.want, got)
+		}
+	}
+}
+
+func TestEvolutionItem(t *testing.T) {
+	config := newTestConfig(t, fakeAPI{
+		"/pokemon/pikachu/": map[string]any{
+			"name":    "pikachu",
+			"species": NamedAPIResource{Name: "pikachu", URL: "BASE/pokemon-species/pikachu/"},
+		},
+		"/pokemon/raichu/": map[string]any{"name": "raichu"},
+		"/pokemon-species/pikachu/": map[string]any{
+			"name":            "pikachu",
+			"evolution_chain": APIResource{URL: "BASE/evolution-chain/10/"},
+		},
+		"/evolution-chain/10/": EvolutionChainEndpoint{ID: 10, Chain: ChainLink{
+			Species: NamedAPIResource{Name: "pichu"},
+			EvolvesTo: []ChainLink{{
+				Species:          NamedAPIResource{Name: "pikachu"},
+				EvolutionDetails: []EvolutionDetail{{Trigger: NamedAPIResource{Name: "level-up"}, MinHappiness: 220}},
+				EvolvesTo: []ChainLink{{
+					Species:          NamedAPIResource{Name: "raichu"},
+					EvolutionDetails: []EvolutionDetail{{Trigger: NamedAPIResource{Name: "use-item"}, Item: &NamedAPIResource{Name: "thunder-stone"}}},
+				}},
+			}},
+		}},
+		"/item/thunder-stone/": ItemEndpoint{Name: "thunder-stone", Category: NamedAPIResource{Name: "evolution"}},
+		"/item/fire-stone/":    ItemEndpoint{Name: "fire-stone", Category: NamedAPIResource{Name: "evolution"}},
+	})
+	config.Inventory = map[string]int{"thunder-stone": 1, "fire-stone": 1}
+	config.Owned[1] = &OwnedPokemon{ID: 1, Species: "pikachu", Level: 10}
+	ctx := context.Background()
+
+	if err := commandUse(ctx, config, "fire-stone", "1"); err == nil {
+		t.Error("expected a fire-stone to have no effect on pikachu")
+	}
+	if config.Inventory["fire-stone"] != 1 {
+		t.Error("expected the fire-stone to be kept")
+	}
+	if err := commandUse(ctx, config, "thunder-stone", "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Owned[1].Species != "raichu" {
+		t.Errorf("expected pikachu to evolve into raichu, got %s", config.Owned[1].Species)
+	}
+	if _, ok := (*config.Pokedex)["raichu"]; !ok {
+		t.Error("expected raichu to be added to the pokedex")
+	}
+	if config.Inventory["thunder-stone"] != 0 {
+		t.Error("expected the thunder-stone to be used up")
+	}
+	if err := commandEvolutions(ctx, config, "pikachu"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	maxLevel     = 100
+	maxEVPerStat = 252
+	maxEVTotal   = 510
+	// friendshipPerLevel is how much friendlier a Pokemon gets with each
+	// level it gains.
+	friendshipPerLevel = 5
+)
+
+type GrowthRateEndpoint struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Formula string `json:"formula"`
+	Levels  []struct {
+		Level      int `json:"level"`
+		Experience int `json:"experience"`
+	} `json:"levels"`
+}
+
+func fetchGrowthRate(ctx context.Context, config *Config, species PokemonSpeciesEndpoint) (GrowthRateEndpoint, error) {
+	data := GrowthRateEndpoint{}
+	err := fetchJSON(ctx, config, species.GrowthRate.URL, &data)
+	return data, err
+}
+
+// experienceAt returns the total experience needed to reach level.
+func (growth GrowthRateEndpoint) experienceAt(level int) int {
+	for _, l := range growth.Levels {
+		if l.Level == level {
+			return l.Experience
+		}
+	}
+	return 0
+}
+
+// pendingMove is a move a Pokemon that already knows four moves wants to
+// learn, waiting for the trainer to pick one to forget.
+type pendingMove struct {
+	Pokemon int    `json:"pokemon"`
+	Move    string `json:"move"`
+}
+
+// experienceYield is the experience for defeating a wild Pokemon alone,
+// as in generations I to IV.
+func experienceYield(baseExperience, level int) int {
+	return max(baseExperience*level/7, 1)
+}
+
+// gainExperience rewards owned for defeating a Pokemon with experience and
+// effort values, leveling it up and teaching it new moves as needed.
+func gainExperience(ctx context.Context, config *Config, owned *OwnedPokemon, defeated PokemonEndpoint, defeatedLevel int) error {
+	data, err := fetchPokemon(ctx, config, owned.Species)
+	if err != nil {
+		return err
+	}
+	species, err := fetchSpecies(ctx, config, data)
+	if err != nil {
+		return err
+	}
+	growth, err := fetchGrowthRate(ctx, config, species)
+	if err != nil {
+		return err
+	}
+	versionGroup, err := currentVersionGroup(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	// Pokemon from saves made before experience existed start at their
+	// level.
+	owned.Experience = max(owned.Experience, growth.experienceAt(owned.Level))
+	gained := experienceYield(defeated.BaseExperience, defeatedLevel)
+	owned.Experience += gained
+	fmt.Printf("%s gained %d EXP. Points!\n", ownedName(ctx, config, owned), gained)
+	gainEVs(owned, defeated)
+
+	leveledUp := false
+	for owned.Level < maxLevel && owned.Experience >= growth.experienceAt(owned.Level+1) {
+		owned.Level++
+		owned.Friendship = min(owned.Friendship+friendshipPerLevel, maxFriendship)
+		leveledUp = true
+		fmt.Printf("%s grew to level %d!\n", ownedName(ctx, config, owned), owned.Level)
+		for _, move := range levelUpMoves(data, versionGroup, owned.Level) {
+			if move.Level == owned.Level {
+				learnMove(ctx, config, owned, move.Move)
+			}
+		}
+	}
+	if leveledUp {
+		_, err = tryEvolve(ctx, config, owned, "level-up", "")
+	}
+	return err
+}
+
+// gainEVs adds the effort values defeated yields, within the caps per stat
+// and in total.
+func gainEVs(owned *OwnedPokemon, defeated PokemonEndpoint) {
+	total := 0
+	for _, value := range owned.EVs {
+		total += value
+	}
+	for _, stat := range defeated.Stats {
+		gain := min(stat.Effort, maxEVPerStat-owned.EVs[stat.Stat.Name], maxEVTotal-total)
+		if gain > 0 {
+			owned.EVs[stat.Stat.Name] += gain
+			total += gain
+		}
+	}
+}
+
+// learnMove teaches owned a move, or asks the trainer which move to forget
+// when it already knows four.
+func learnMove(ctx context.Context, config *Config, owned *OwnedPokemon, move string) {
+	for _, known := range owned.Moves {
+		if known == move {
+			return
+		}
+	}
+	if len(owned.Moves) < maxKnownMoves {
+		owned.Moves = append(owned.Moves, move)
+		fmt.Printf("%s learned %s!\n", ownedName(ctx, config, owned), localName(ctx, config, "move", move))
+		return
+	}
+	config.PendingMoves = append(config.PendingMoves, pendingMove{Pokemon: owned.ID, Move: move})
+	if len(config.PendingMoves) == 1 {
+		promptPendingMove(ctx, config)
+	}
+}
+
+func promptPendingMove(ctx context.Context, config *Config) {
+	pending := config.PendingMoves[0]
+	owned := config.Owned[pending.Pokemon]
+	move := localName(ctx, config, "move", pending.Move)
+	fmt.Printf("%s wants to learn %s, but already knows %d moves: %s.\n", ownedName(ctx, config, owned), move,
+		len(owned.Moves), strings.Join(localNames(ctx, config, "move", owned.Moves), ", "))
+	fmt.Printf("Use forget <move> to replace one with %s, or forget none to not learn it.\n", move)
+}
+
+func commandForget(ctx context.Context, config *Config, params ...string) error {
+	if len(config.PendingMoves) == 0 {
+		return errors.New("none of your pokemon is trying to learn a move")
+	}
+	if len(params) == 0 {
+		return errors.New("use forget <move> or forget none")
+	}
+	pending := config.PendingMoves[0]
+	owned, ok := config.Owned[pending.Pokemon]
+	if !ok {
+		config.PendingMoves = config.PendingMoves[1:]
+		return fmt.Errorf("you no longer have pokemon #%d", pending.Pokemon)
+	}
+
+	forgotten := resolveNameAmong(ctx, config, "move", params[0], owned.Moves)
+	if forgotten == "none" {
+		fmt.Printf("%s did not learn %s.\n", ownedName(ctx, config, owned), localName(ctx, config, "move", pending.Move))
+	} else {
+		index := -1
+		for i, known := range owned.Moves {
+			if known == forgotten {
+				index = i
+			}
+		}
+		if index < 0 {
+			return fmt.Errorf("%s does not know %s", ownedName(ctx, config, owned), params[0])
+		}
+		owned.Moves[index] = pending.Move
+		fmt.Printf("1, 2 and... Poof! %s forgot %s and learned %s!\n", ownedName(ctx, config, owned),
+			localName(ctx, config, "move", forgotten), localName(ctx, config, "move", pending.Move))
+	}
+
+	config.PendingMoves = config.PendingMoves[1:]
+	if len(config.PendingMoves) > 0 {
+		promptPendingMove(ctx, config)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestGainExperienceLevelsUp(t *testing.T) {
+	config := newBattleConfig(t)
+	ctx := context.Background()
+	owned := config.Owned[1]
+	owned.Moves = []string{"thunder-shock", "growl", "tail-whip", "quick-attack"}
+	owned.EVs = map[string]int{"speed": 251}
+	pidgey, err := fetchPokemon(ctx, config, "pidgey")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pidgey.Stats[5].Effort = 1
+	pidgey.Stats[0].Effort = 3
+
+	// 50 * 140 / 7 = 1000 EXP on top of the 8000 for level 20 is not enough
+	// for level 21 at 9261.
+	if err := gainExperience(ctx, config, owned, pidgey, 140); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owned.Level != 20 || owned.Experience != 9000 {
+		t.Errorf("expected level 20 with 9000 EXP, got level %d with %d", owned.Level, owned.Experience)
+	}
+	if owned.EVs["speed"] != 252 || owned.EVs["hp"] != 3 {
+		t.Errorf("expected EVs capped at 252 speed and 3 hp, got %v", owned.EVs)
+	}
+
+	if err := gainExperience(ctx, config, owned, pidgey, 140); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owned.Level != 21 {
+		t.Errorf("expected level 21, got %d", owned.Level)
+	}
+	expected := []pendingMove{{Pokemon: 1, Move: "thunderbolt"}}
+	if !reflect.DeepEqual(config.PendingMoves, expected) {
+		t.Fatalf("expected thunderbolt to wait for a move to forget, got %v", config.PendingMoves)
+	}
+
+	if err := commandForget(ctx, config, "surf"); err == nil {
+		t.Error("expected an error forgetting a move pikachu does not know")
+	}
+	if err := commandForget(ctx, config, "growl"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(owned.Moves, []string{"thunder-shock", "thunderbolt", "tail-whip", "quick-attack"}) {
+		t.Errorf("expected growl to be replaced by thunderbolt, got %v", owned.Moves)
+	}
+	if len(config.PendingMoves) != 0 {
+		t.Errorf("expected no pending moves, got %v", config.PendingMoves)
+	}
+}
+
+func TestLearnMoveWithRoom(t *testing.T) {
+	config := &Config{}
+	owned := &OwnedPokemon{ID: 1, Species: "pikachu", Moves: []string{"thunder-shock"}}
+
+	learnMove(context.Background(), config, owned, "thunder-shock")
+	learnMove(context.Background(), config, owned, "thunderbolt")
+	if !reflect.DeepEqual(owned.Moves, []string{"thunder-shock", "thunderbolt"}) {
+		t.Errorf("expected thunderbolt to be learned once, got %v", owned.Moves)
+	}
+	if len(config.PendingMoves) != 0 {
+		t.Errorf("expected no pending moves, got %v", config.PendingMoves)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBaseURL = "https://pokeapi.co/api/v2"
+	// requestTimeout bounds a single HTTP round trip to the PokeAPI.
+	requestTimeout = 10 * time.Second
+	// commandTimeout bounds everything a single REPL command does.
+	commandTimeout = 30 * time.Second
+	// fetchWorkers bounds how many requests a command makes at once.
+	fetchWorkers = 8
+)
+
+// fetchEach calls fetch for every index below count, with at most
+// fetchWorkers of them running at once. It returns the error of the
+// lowest index that failed.
+func fetchEach(count int, fetch func(i int) error) error {
+	errs := make([]error, count)
+	slots := make(chan struct{}, fetchWorkers)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			errs[i] = fetch(i)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchJSON decodes the resource at url into target, serving it from the
+// cache when possible. The request is aborted as soon as ctx is done.
+func fetchJSON(ctx context.Context, config *Config, url string, target any) error {
+	var pokeData []byte
+	cacheEntry, ok := config.Cache.Get(url)
+	if ok {
+		pokeData = cacheEntry
+	} else {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := config.Client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("GET %s: %s", url, resp.Status)
+		}
+
+		pokeData, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		config.Cache.Add(url, pokeData)
+	}
+
+	return json.Unmarshal(pokeData, target)
+}
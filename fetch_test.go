@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// newBlockingConfig returns a Config whose PokeAPI never answers until the
+// test ends.
+func newBlockingConfig(t *testing.T) *Config {
+	t.Helper()
+	release := make(chan struct{})
+	config := newTestConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(func() { close(release) })
+	return config
+}
+
+func TestFetchJSONCanceled(t *testing.T) {
+	config := newBlockingConfig(t)
+	url := config.BaseURL + "/pokemon/pikachu/"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	start := time.Now()
+	err := fetchJSON(ctx, config, url, &PokemonEndpoint{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected fetchJSON to return promptly, took %v", elapsed)
+	}
+	if _, ok := config.Cache.Get(url); ok {
+		t.Error("expected a canceled request not to be cached")
+	}
+}
+
+func TestFetchJSONTimeout(t *testing.T) {
+	config := newBlockingConfig(t)
+	url := config.BaseURL + "/pokemon/pikachu/"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := fetchJSON(ctx, config, url, &PokemonEndpoint{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected fetchJSON to return promptly, took %v", elapsed)
+	}
+	if _, ok := config.Cache.Get(url); ok {
+		t.Error("expected a timed out request not to be cached")
+	}
+}
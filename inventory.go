@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+type ItemEndpoint struct {
+	ID       int              `json:"id"`
+	Name     string           `json:"name"`
+	Names    []Name           `json:"names"`
+	Cost     int              `json:"cost"`
+	Category NamedAPIResource `json:"category"`
+	Effects  []struct {
+		Effect      string           `json:"effect"`
+		ShortEffect string           `json:"short_effect"`
+		Language    NamedAPIResource `json:"language"`
+	} `json:"effect_entries"`
+}
+
+// starterInventory is the bag a new trainer starts with.
+func starterInventory() map[string]int {
+	return map[string]int{"poke-ball": 10}
+}
+
+func fetchItem(ctx context.Context, config *Config, item string) (ItemEndpoint, error) {
+	data := ItemEndpoint{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/item/%s/", config.BaseURL, item), &data)
+	return data, err
+}
+
+// shortEffect returns the English short effect of an item.
+func (item ItemEndpoint) shortEffect() string {
+	for _, effect := range item.Effects {
+		if effect.Language.Name == "en" {
+			return effect.ShortEffect
+		}
+	}
+	return ""
+}
+
+// takeItem removes one item from the bag.
+func takeItem(config *Config, item string) error {
+	if config.Inventory[item] <= 0 {
+		return fmt.Errorf("you have no %s left", item)
+	}
+	config.Inventory[item]--
+	if config.Inventory[item] == 0 {
+		delete(config.Inventory, item)
+	}
+	return nil
+}
+
+func commandBag(ctx context.Context, config *Config) error {
+	if len(config.Inventory) == 0 {
+		fmt.Println("Your bag is empty.")
+		return nil
+	}
+	items := make([]string, 0, len(config.Inventory))
+	for item := range config.Inventory {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+
+	fmt.Println("Your bag:")
+	for _, item := range items {
+		data, err := fetchItem(ctx, config, item)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("- %s x%d (%s): %s\n", pickName(config, "item", item, data.Names), config.Inventory[item],
+			data.Category.Name, data.shortEffect())
+	}
+	return nil
+}
+
+func commandUse(ctx context.Context, config *Config, params ...string) error {
+	if len(params) == 0 {
+		return errors.New("missing item name")
+	}
+	item := resolveNameAmong(ctx, config, "item", params[0], bagItems(config))
+	if config.Inventory[item] <= 0 {
+		return fmt.Errorf("you have no %s", item)
+	}
+	if _, ok := ballModifiers[item]; ok {
+		return commandCatch(ctx, config, "--ball", item)
+	}
+	if _, ok := healAmounts[item]; ok {
+		return useHealingItem(ctx, config, item, params[1:])
+	}
+	data, err := fetchItem(ctx, config, item)
+	if err != nil {
+		return err
+	}
+	if data.Category.Name == "evolution" {
+		return useEvolutionItem(ctx, config, item, params[1:])
+	}
+	return fmt.Errorf("%s can't be used right now", item)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func newCatchConfig(t *testing.T) *Config {
+	t.Helper()
+	config := newTestConfig(t, fakeAPI{
+		"/pokemon/pidgey/": map[string]any{
+			"name":    "pidgey",
+			"species": NamedAPIResource{Name: "pidgey", URL: "BASE/pokemon-species/pidgey/"},
+		},
+		"/pokemon-species/pidgey/": PokemonSpeciesEndpoint{Name: "pidgey", CaptureRate: 255, GenderRate: 4,
+			GrowthRate: NamedAPIResource{Name: "medium", URL: "BASE/growth-rate/medium/"}},
+		"/growth-rate/medium/": mediumGrowthRate(),
+		"/nature/":             NamedAPIResourceList{Count: 1, Results: []NamedAPIResource{{Name: "hardy"}}},
+	})
+	config.FreeMode = true
+	return config
+}
+
+func TestCatchConsumesBalls(t *testing.T) {
+	config := newCatchConfig(t)
+	config.Inventory = map[string]int{"poke-ball": 2, "master-ball": 1}
+	ctx := context.Background()
+
+	if err := commandCatch(ctx, config, "pidgey"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Inventory["poke-ball"] != 1 {
+		t.Errorf("expected 1 poke-ball left, got %d", config.Inventory["poke-ball"])
+	}
+	if caught := config.Owned[1]; caught == nil || caught.Experience != caught.Level*caught.Level*caught.Level {
+		t.Errorf("expected a caught pokemon to start with the experience of its level, got %+v", caught)
+	}
+
+	if err := commandCatch(ctx, config, "pidgey", "--ball", "master-ball"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := config.Inventory["master-ball"]; ok {
+		t.Error("expected the last master-ball to be removed from the bag")
+	}
+	if _, ok := (*config.Pokedex)["pidgey"]; !ok {
+		t.Error("expected a master-ball to always catch")
+	}
+
+	if err := commandCatch(ctx, config, "pidgey", "--ball", "master-ball"); err == nil {
+		t.Error("expected an error without master-balls")
+	}
+}
+
+func TestCatchFailureKeepsBalls(t *testing.T) {
+	config := newCatchConfig(t)
+	config.Inventory = map[string]int{"poke-ball": 1}
+
+	if err := commandCatch(context.Background(), config, "missingno"); err == nil {
+		t.Fatal("expected an error for an unknown pokemon")
+	}
+	if config.Inventory["poke-ball"] != 1 {
+		t.Errorf("expected the ball not to be thrown, got %d left", config.Inventory["poke-ball"])
+	}
+}
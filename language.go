@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// defaultLanguage is the language names fall back to when the selected
+// language has no translation.
+const defaultLanguage = "en"
+
+type LanguageEndpoint struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Names []Name `json:"names"`
+}
+
+// namedEndpoint holds the localized names every named PokeAPI resource
+// such as a move, type or item has.
+type namedEndpoint struct {
+	Name  string `json:"name"`
+	Names []Name `json:"names"`
+}
+
+// setLanguage selects the language names are shown in, after checking
+// that the PokeAPI knows it.
+func setLanguage(ctx context.Context, config *Config, language string) error {
+	data := LanguageEndpoint{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/language/%s/", config.BaseURL, language), &data)
+	if err != nil {
+		return fmt.Errorf("unknown language %s: %w", language, err)
+	}
+	config.Language = data.Name
+
+	// The trainer's own Pokemon and items are named by lookups that can't
+	// fetch anything, so learn their names up front.
+	for _, owned := range config.Owned {
+		localName(ctx, config, "pokemon-species", owned.Species)
+	}
+	localNames(ctx, config, "item", bagItems(config))
+	return nil
+}
+
+// displayLanguage returns the language text such as Pokedex entries is
+// shown in.
+func displayLanguage(config *Config) string {
+	if config.Language == "" {
+		return defaultLanguage
+	}
+	return config.Language
+}
+
+// pickName returns the name in the selected language, the English name
+// when there is no translation, or the slug when there is neither. Without
+// a language setting slugs are shown as they are.
+func pickName(config *Config, kind, slug string, names []Name) string {
+	if config.Language == "" {
+		return slug
+	}
+	indexNames(config, kind, slug, names)
+	fallback := slug
+	for _, name := range names {
+		switch name.Language.Name {
+		case config.Language:
+			return name.Name
+		case defaultLanguage:
+			fallback = name.Name
+		}
+	}
+	return fallback
+}
+
+// localName returns the localized name of a resource such as the move
+// thunderbolt, where kind is the endpoint, like move or pokemon-species.
+// Names that can't be fetched are shown as slugs.
+func localName(ctx context.Context, config *Config, kind, slug string) string {
+	if config.Language == "" {
+		return slug
+	}
+	data := namedEndpoint{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/%s/%s/", config.BaseURL, kind, slug), &data)
+	if err != nil {
+		return slug
+	}
+	return pickName(config, kind, slug, data.Names)
+}
+
+func localNames(ctx context.Context, config *Config, kind string, slugs []string) []string {
+	names := make([]string, 0, len(slugs))
+	for _, slug := range slugs {
+		names = append(names, localName(ctx, config, kind, slug))
+	}
+	return names
+}
+
+// nameKey turns a name into the form typed as an argument, so Forêt de Jade
+// is typed as forêt-de-jade.
+func nameKey(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
+
+// indexNames remembers the names of a resource in every language, so they
+// can be typed as arguments from then on.
+func indexNames(config *Config, kind, slug string, names []Name) {
+	if config.NameIndex == nil {
+		config.NameIndex = make(map[string]map[string]string)
+	}
+	if config.NameIndex[kind] == nil {
+		config.NameIndex[kind] = make(map[string]string)
+	}
+	for _, name := range names {
+		config.NameIndex[kind][nameKey(name.Name)] = slug
+	}
+}
+
+// resolveName turns a localized name shown earlier back into the slug of
+// a resource of the given kind. Anything else is taken to be a slug
+// already. The names shown are kept in the save, so they stay valid after
+// a restart.
+func resolveName(config *Config, kind, name string) string {
+	if slug, ok := config.NameIndex[kind][nameKey(name)]; ok {
+		return slug
+	}
+	return name
+}
+
+// resolveNameAmong is resolveName for arguments that can only be one of
+// candidates, like an item in the bag or a move the active Pokemon knows.
+// Localized names not shown yet are looked up among the candidates, so
+// they can be typed without being listed first.
+func resolveNameAmong(ctx context.Context, config *Config, kind, name string, candidates []string) string {
+	slug := resolveName(config, kind, name)
+	if slug != name || config.Language == "" || containsString(candidates, name) {
+		return slug
+	}
+	for _, candidate := range candidates {
+		localName(ctx, config, kind, candidate)
+	}
+	return resolveName(config, kind, name)
+}
+
+// knownSpecies lists the species the trainer has come across, which are
+// the ones they can type by their localized name.
+func knownSpecies(config *Config) []string {
+	known := make(map[string]bool)
+	for name := range *config.Pokedex {
+		known[name] = true
+	}
+	for name := range config.Seen {
+		known[name] = true
+	}
+	if config.Wild != nil {
+		known[config.Wild.Name] = true
+	}
+	return sortedKeys(known)
+}
+
+func bagItems(config *Config) []string {
+	return sortedKeys(config.Inventory)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
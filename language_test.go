@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalName(t *testing.T) {
+	config := newTestConfig(t, fakeAPI{
+		"/language/fr/": LanguageEndpoint{Name: "fr"},
+		"/move/thunderbolt/": namedEndpoint{Name: "thunderbolt", Names: []Name{
+			{Name: "Thunderbolt", Language: NamedAPIResource{Name: "en"}},
+			{Name: "Tonnerre", Language: NamedAPIResource{Name: "fr"}},
+		}},
+		"/move/tackle/": namedEndpoint{Name: "tackle", Names: []Name{
+			{Name: "Tackle", Language: NamedAPIResource{Name: "en"}},
+		}},
+	})
+	ctx := context.Background()
+
+	if got := localName(ctx, config, "move", "thunderbolt"); got != "thunderbolt" {
+		t.Errorf("expected slugs without a language setting, got %q", got)
+	}
+	if err := commandSet(ctx, config, "language", "FR"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := commandSet(ctx, config, "language", "xx"); err == nil {
+		t.Error("expected an error for an unknown language")
+	}
+	if config.Language != "fr" {
+		t.Fatalf("expected language fr, got %q", config.Language)
+	}
+
+	cases := []struct {
+		move     string
+		expected string
+	}{
+		{"thunderbolt", "Tonnerre"},
+		{"tackle", "Tackle"},
+		{"splash", "splash"},
+	}
+	for _, c := range cases {
+		if got := localName(ctx, config, "move", c.move); got != c.expected {
+			t.Errorf("%s: expected %q, got %q", c.move, c.expected, got)
+		}
+	}
+
+	if got := resolveName(config, "move", "tonnerre"); got != "thunderbolt" {
+		t.Errorf("expected tonnerre to resolve to thunderbolt, got %q", got)
+	}
+	if got := resolveName(config, "type", "tonnerre"); got != "tonnerre" {
+		t.Errorf("expected move names not to resolve as types, got %q", got)
+	}
+}
+
+func TestResolveNameAmong(t *testing.T) {
+	config := newTestConfig(t, fakeAPI{
+		"/language/ja/": LanguageEndpoint{Name: "ja"},
+		"/item/poke-ball/": namedEndpoint{Name: "poke-ball", Names: []Name{
+			{Name: "モンスターボール", Language: NamedAPIResource{Name: "ja"}},
+		}},
+		"/item/great-ball/": namedEndpoint{Name: "great-ball", Names: []Name{
+			{Name: "スーパーボール", Language: NamedAPIResource{Name: "ja"}},
+		}},
+		"/pokemon-species/pikachu/": namedEndpoint{Name: "pikachu", Names: []Name{
+			{Name: "ピカチュウ", Language: NamedAPIResource{Name: "ja"}},
+		}},
+	})
+	ctx := context.Background()
+	config.Owned[1] = &OwnedPokemon{ID: 1, Species: "pikachu", Level: 5}
+	if err := setLanguage(ctx, config, "ja"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := resolveName(config, "pokemon-species", "ピカチュウ"); got != "pikachu" {
+		t.Errorf("expected owned species to be known after setting the language, got %q", got)
+	}
+	if got := resolveName(config, "item", "モンスターボール"); got != "poke-ball" {
+		t.Errorf("expected bag items to be known after setting the language, got %q", got)
+	}
+	if got := resolveNameAmong(ctx, config, "item", "スーパーボール", martStock); got != "great-ball" {
+		t.Errorf("expected スーパーボール to resolve among the mart stock, got %q", got)
+	}
+	if got := resolveNameAmong(ctx, config, "item", "great-ball", martStock); got != "great-ball" {
+		t.Errorf("expected slugs to pass through, got %q", got)
+	}
+	if got := describeOwned(ctx, config, config.Owned[1]); got != "#1 ピカチュウ Lv. 5" {
+		t.Errorf("expected the localized species, got %q", got)
+	}
+
+	config.SavePath = filepath.Join(t.TempDir(), "save.json")
+	if err := saveGame(config); err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+	pokedexMap := make(map[string]PokemonEndpoint)
+	loaded := &Config{SavePath: config.SavePath, Pokedex: &pokedexMap}
+	if err := loadGame(loaded); err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if got := resolveName(loaded, "item", "スーパーボール"); got != "great-ball" {
+		t.Errorf("expected names shown before to be known after loading, got %q", got)
+	}
+}
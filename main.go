@@ -2,12 +2,14 @@ package main
 
 import (
 	"bufio"
-	"encoding/json"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"io"
 	"math/rand"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
 	"time"
 
@@ -17,14 +19,34 @@ import (
 type cliCommand struct {
 	name        string
 	description string
-	callback    func(params ...string) error
+	callback    func(ctx context.Context, params ...string) error
 }
 
 type Config struct {
-	Next     *string
-	Previous *string
-	Pokedex  *map[string]PokemonEndpoint
-	Cache    *pokecache.Cache
+	BaseURL      string
+	SavePath     string
+	Map          Pagination
+	CurrentArea  string
+	Explored     map[string]bool
+	Seen         map[string]bool
+	FreeMode     bool
+	Version      string
+	Language     string
+	NameIndex    map[string]map[string]string
+	Wild         *WildPokemon
+	Battle       *Battle
+	TypeChart    typeChart
+	PendingMoves []pendingMove
+	Seed         int64
+	Rand         *rand.Rand
+	Inventory    map[string]int
+	Money        int
+	Pokedex      *map[string]PokemonEndpoint
+	Owned        map[int]*OwnedPokemon
+	Party        []int
+	Boxes        [][]int
+	Cache        *pokecache.Cache
+	Client       *http.Client
 }
 
 type LocationAreaResponse struct {
@@ -57,14 +79,8 @@ type LocationAreaEndpoint struct {
 		Name string `json:"name,omitempty"`
 		URL  string `json:"url,omitempty"`
 	} `json:"location,omitempty"`
-	Name  string `json:"name,omitempty"`
-	Names []struct {
-		Language struct {
-			Name string `json:"name,omitempty"`
-			URL  string `json:"url,omitempty"`
-		} `json:"language,omitempty"`
-		Name string `json:"name,omitempty"`
-	} `json:"names,omitempty"`
+	Name              string `json:"name,omitempty"`
+	Names             []Name `json:"names,omitempty"`
 	PokemonEncounters []struct {
 		Pokemon struct {
 			Name string `json:"name,omitempty"`
@@ -72,9 +88,9 @@ type LocationAreaEndpoint struct {
 		} `json:"pokemon,omitempty"`
 		VersionDetails []struct {
 			EncounterDetails []struct {
-				Chance          int   `json:"chance,omitempty"`
-				ConditionValues []any `json:"condition_values,omitempty"`
-				MaxLevel        int   `json:"max_level,omitempty"`
+				Chance          int                `json:"chance,omitempty"`
+				ConditionValues []NamedAPIResource `json:"condition_values,omitempty"`
+				MaxLevel        int                `json:"max_level,omitempty"`
 				Method          struct {
 					Name string `json:"name,omitempty"`
 					URL  string `json:"url,omitempty"`
@@ -366,56 +382,217 @@ func getCommands(config *Config) map[string]cliCommand {
 			callback:    commandHelp,
 		},
 		"exit": {
-			name:        "exit",
-			description: "Exits the Pokedex",
-			callback:    commandExit,
+			name:        "exit [--no-save]",
+			description: "Saves the game and exits the Pokedex, or exits without saving",
+			callback:    func(ctx context.Context, params ...string) error { return commandExit(config, params...) },
+		},
+		"save": {
+			name:        "save",
+			description: "Saves your progress",
+			callback:    func(ctx context.Context, params ...string) error { return commandSave(ctx, config) },
 		},
 		"map": {
-			name:        "map",
-			description: "Displays the names of 20 location areas in the Pokemon world.",
-			callback:    func(params ...string) error { return commandMap(config) },
+			name:        "map [first|last|page <n>|size <n>]",
+			description: "Displays the next page of location areas, jumps to a page or changes the page size",
+			callback:    func(ctx context.Context, params ...string) error { return commandMap(ctx, config, params...) },
 		},
 		"mapb": {
 			name:        "mapb",
-			description: "Displays the names of previously displayed 20 location areas in the Pokemon world.",
-			callback:    func(params ...string) error { return commandMapb(config) },
+			description: "Displays the previous page of location areas",
+			callback:    func(ctx context.Context, params ...string) error { return commandMapb(ctx, config) },
+		},
+		"areas": {
+			name:        "areas [search <substring>] [--region <region>]",
+			description: "Finds location areas by name and/or region, with their location and region",
+			callback:    func(ctx context.Context, params ...string) error { return commandAreas(ctx, config, params...) },
+		},
+		"set": {
+			name:        "set <setting> <value>",
+			description: "Changes a setting: version <game version|all>, language <code such as fr or ja>",
+			callback:    func(ctx context.Context, params ...string) error { return commandSet(ctx, config, params...) },
+		},
+		"seed": {
+			name:        "seed [n]",
+			description: "Shows the random seed, or restarts the random source from seed n to reproduce a run",
+			callback:    func(ctx context.Context, params ...string) error { return commandSeed(ctx, config, params...) },
+		},
+		"travel": {
+			name:        "travel <area>",
+			description: "Travels to a location area and shows the areas nearby",
+			callback: func(ctx context.Context, params ...string) error {
+				if len(params) == 0 {
+					return errors.New("missing area name")
+				}
+				return commandTravel(ctx, config, resolveNameAmong(ctx, config, "location-area", params[0], sortedKeys(config.Explored)))
+			},
 		},
 		"explore": {
-			name:        "explore <area>",
-			description: "Returns and displays the pokemons of a given area",
-			callback: func(params ...string) error {
-				area := params[0]
-				return exploreArea(config, area)
+			name:        "explore [area]",
+			description: "Returns and displays the pokemons of the area you are in",
+			callback: func(ctx context.Context, params ...string) error {
+				area, err := resolveArea(config, params)
+				if err != nil {
+					return err
+				}
+				return exploreArea(ctx, config, area)
 			},
 		},
+		"encounter": {
+			name:        "encounter [method]",
+			description: "Looks for a wild pokemon in the area you are in, optionally by an encounter method such as walk or surf",
+			callback:    func(ctx context.Context, params ...string) error { return commandEncounter(ctx, config, params...) },
+		},
 		"catch": {
-			name:        "catch <pokemon>",
-			description: "Try to catch a pokemon of a given name",
-			callback: func(params ...string) error {
-				pokemon := params[0]
-				return catchPokemon(config, pokemon)
-			},
+			name:        "catch [pokemon] [--ball <ball>]",
+			description: "Throws a poke-ball, great-ball, ultra-ball or master-ball at the wild pokemon you encountered, or at a pokemon of a given name in the area you are in",
+			callback:    func(ctx context.Context, params ...string) error { return commandCatch(ctx, config, params...) },
 		},
 
+		"battle": {
+			name:        "battle",
+			description: "Battles the wild pokemon you encountered with the first party pokemon able to fight",
+			callback:    func(ctx context.Context, params ...string) error { return commandBattle(ctx, config) },
+		},
+		"fight": {
+			name:        "fight <move>",
+			description: "Attacks with a move during a battle",
+			callback:    func(ctx context.Context, params ...string) error { return commandFight(ctx, config, params...) },
+		},
+		"switch": {
+			name:        "switch <id>",
+			description: "Sends out another party pokemon during a battle",
+			callback:    func(ctx context.Context, params ...string) error { return commandSwitch(ctx, config, params...) },
+		},
+		"run": {
+			name:        "run",
+			description: "Tries to run away from a battle",
+			callback:    func(ctx context.Context, params ...string) error { return commandRun(ctx, config) },
+		},
+		"forget": {
+			name:        "forget <move>|none",
+			description: "Replaces a move so your pokemon can learn a new one, or gives up learning it",
+			callback:    func(ctx context.Context, params ...string) error { return commandForget(ctx, config, params...) },
+		},
+		"heal": {
+			name:        "heal",
+			description: "Restores your party at the Pokemon Center",
+			callback:    func(ctx context.Context, params ...string) error { return commandHeal(config) },
+		},
+		"dex": {
+			name:        "dex <pokemon>",
+			description: "Shows the pokedex entry of a pokemon species",
+			callback:    func(ctx context.Context, params ...string) error { return commandDex(ctx, config, params...) },
+		},
+		"abilities": {
+			name:        "abilities <pokemon>",
+			description: "Shows the abilities of a pokemon and what they do",
+			callback:    func(ctx context.Context, params ...string) error { return commandAbilities(ctx, config, params...) },
+		},
+		"moves": {
+			name:        "moves <pokemon> [--method level-up|machine|egg|tutor] [--version-group <group>]",
+			description: "Lists the moves a pokemon can learn, in the selected version unless a version group is given",
+			callback:    func(ctx context.Context, params ...string) error { return commandMoves(ctx, config, params...) },
+		},
+		"where": {
+			name:        "where <pokemon>",
+			description: "Lists the areas where a pokemon can be found, with methods, levels and chances",
+			callback:    func(ctx context.Context, params ...string) error { return commandWhere(ctx, config, params...) },
+		},
+		"compare": {
+			name:        "compare <pokemon> <pokemon> [...]",
+			description: "Compares the stats, types, abilities, size and weaknesses of pokemon side by side",
+			callback:    func(ctx context.Context, params ...string) error { return commandCompare(ctx, config, params...) },
+		},
+		"evolutions": {
+			name:        "evolutions <pokemon>",
+			description: "Shows the evolution chain of a pokemon and what triggers each evolution",
+			callback:    func(ctx context.Context, params ...string) error { return commandEvolutions(ctx, config, params...) },
+		},
+		"weakness": {
+			name:        "weakness <pokemon>",
+			description: "Shows which attacking types are strong or weak against a pokemon",
+			callback:    func(ctx context.Context, params ...string) error { return commandWeakness(ctx, config, params...) },
+		},
+		"matchup": {
+			name:        "matchup <attacker> <defender>",
+			description: "Shows how the types of two pokemon fare against each other",
+			callback:    func(ctx context.Context, params ...string) error { return commandMatchup(ctx, config, params...) },
+		},
+		"bag": {
+			name:        "bag",
+			description: "Lists the items in your bag",
+			callback:    func(ctx context.Context, params ...string) error { return commandBag(ctx, config) },
+		},
+		"use": {
+			name:        "use <item> [pokemon]",
+			description: "Uses an item from your bag, such as throwing a ball at the wild pokemon or a potion on one of yours",
+			callback:    func(ctx context.Context, params ...string) error { return commandUse(ctx, config, params...) },
+		},
+		"shop": {
+			name:        "shop",
+			description: "Lists what the Poke Mart sells and your money",
+			callback:    func(ctx context.Context, params ...string) error { return commandShop(ctx, config) },
+		},
+		"buy": {
+			name:        "buy <item> [qty]",
+			description: "Buys items at the Poke Mart",
+			callback:    func(ctx context.Context, params ...string) error { return commandBuy(ctx, config, params...) },
+		},
+		"sell": {
+			name:        "sell <item> [qty]",
+			description: "Sells items from your bag for half their price",
+			callback:    func(ctx context.Context, params ...string) error { return commandSell(ctx, config, params...) },
+		},
 		"inspect": {
-			name:        "inspect <pokemon>",
-			description: "Get information of a pokemon you just caught",
-			callback: func(params ...string) error {
+			name:        "inspect <pokemon|id>",
+			description: "Get information of a pokemon you caught, with its actual stats",
+			callback: func(ctx context.Context, params ...string) error {
+				if len(params) == 0 {
+					return errors.New("missing pokemon name or ID")
+				}
 				pokemon := params[0]
-				return inspectPokemon(config, pokemon)
+				return inspectPokemon(ctx, config, pokemon)
 			},
 		},
+		"party": {
+			name:        "party [add <id>|remove <id>|swap <id> <id>]",
+			description: "Shows your party of up to 6 pokemon, or changes who is in it",
+			callback:    func(ctx context.Context, params ...string) error { return commandParty(ctx, config, params...) },
+		},
+		"box": {
+			name:        "box list|view <n>|move <id> <n>",
+			description: "Manages the pokemon stored in your PC boxes",
+			callback:    func(ctx context.Context, params ...string) error { return commandBox(ctx, config, params...) },
+		},
+		"nickname": {
+			name:        "nickname <pokemon|id> <name>",
+			description: "Gives one of your pokemon a nickname",
+			callback:    func(ctx context.Context, params ...string) error { return commandNickname(ctx, config, params...) },
+		},
+		"note": {
+			name:        "note <pokemon|id> <text>",
+			description: "Adds a note to one of your pokemon",
+			callback:    func(ctx context.Context, params ...string) error { return commandNote(ctx, config, params...) },
+		},
+		"tag": {
+			name:        "tag <pokemon|id> <tag>",
+			description: "Tags one of your pokemon, to filter the pokedex by",
+			callback:    func(ctx context.Context, params ...string) error { return commandTag(ctx, config, params...) },
+		},
+		"completion": {
+			name:        "completion [pokedex|generation]",
+			description: "Shows how much of the national and regional pokedexes you have seen and caught, and what is missing from one",
+			callback:    func(ctx context.Context, params ...string) error { return commandCompletion(ctx, config, params...) },
+		},
 		"pokedex": {
-			name:        "pokedex",
-			description: "See all your caught pokemon",
-			callback: func(params ...string) error {
-				return getPokedex(config)
-			},
+			name:        "pokedex [--sort dex|name|caught|bst|<stat>] [--reverse] [--type t] [--generation n] [--tag t] [--min <stat>=n] [--max <stat>=n] [--page n] [--size n]",
+			description: "See all your caught pokemon, sorted, filtered and a page at a time",
+			callback:    func(ctx context.Context, params ...string) error { return commandPokedex(ctx, config, params...) },
 		},
 	}
 }
 
-func commandHelp(params ...string) error {
+func commandHelp(ctx context.Context, params ...string) error {
 	var config Config
 	fmt.Println("Available commands:")
 	commands := getCommands(&config)
@@ -425,108 +602,97 @@ func commandHelp(params ...string) error {
 	return nil
 }
 
-func commandExit(params ...string) error {
+func commandExit(config *Config, params ...string) error {
+	if len(params) > 0 && params[0] != "--no-save" {
+		return fmt.Errorf("unknown exit option %q", params[0])
+	}
+	if len(params) == 0 {
+		err := saveGame(config)
+		if err != nil {
+			return fmt.Errorf("could not save, not exiting, use exit --no-save to quit anyway: %w", err)
+		}
+	}
 	fmt.Println("Exiting Pokedex...")
 	os.Exit(0)
 	return nil
 }
 
-func exploreArea(config *Config, area string) error {
-	var pokeData []byte
-	url := fmt.Sprintf("https://pokeapi.co/api/v2/location-area/%s/", area)
-	data := LocationAreaEndpoint{}
-	cacheEntry, ok := config.Cache.Get(url)
-	if ok {
-		pokeData = cacheEntry
-	} else {
-		resp, err := http.Get(url)
-		if err != nil {
-			return err
-		}
-
-		pokeData, err = io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
-		config.Cache.Add(url, pokeData)
-	}
-
-	err := json.Unmarshal(pokeData, &data)
+func exploreArea(ctx context.Context, config *Config, area string) error {
+	data, err := fetchArea(ctx, config, area)
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Exploring %s...\n", area)
+	config.Explored[area] = true
+	fmt.Printf("Exploring %s...\n", pickName(config, "location-area", area, data.Names))
 	fmt.Println("Found Pokemon:")
 	for _, pokemon := range data.PokemonEncounters {
-		fmt.Println("-", pokemon.Pokemon.Name)
+		var chances []string
+		for _, version := range pokemon.VersionDetails {
+			if inVersion(config, version.Version.Name) {
+				chances = append(chances, fmt.Sprintf("%s %d%%", version.Version.Name, version.MaxChance))
+			}
+		}
+		if len(chances) > 0 {
+			markSeen(config, pokemon.Pokemon.Name)
+			fmt.Printf("- %s (%s)\n", localName(ctx, config, "pokemon-species", pokemon.Pokemon.Name), strings.Join(chances, ", "))
+		}
 	}
 	return nil
 }
-func catchPokemon(config *Config, pokemon string) error {
-	var pokeData []byte
-	url := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%s/", pokemon)
-	data := PokemonEndpoint{}
-	cacheEntry, ok := config.Cache.Get(url)
-	if ok {
-		pokeData = cacheEntry
-	} else {
-		resp, err := http.Get(url)
-		if err != nil {
-			return err
-		}
-
-		pokeData, err = io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
-		config.Cache.Add(url, pokeData)
+func inspectPokemon(ctx context.Context, config *Config, pokemon string) error {
+	owned, err := findOwned(config, pokemon)
+	if err != nil {
+		return err
 	}
-
-	err := json.Unmarshal(pokeData, &data)
+	pokemonData, err := pokedexPokemon(ctx, config, owned.Species)
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Throwing a ball at %s...\n", pokemon)
-	expProbability := 1.0 - float64(data.BaseExperience)/1000.0
-
-	if rand.Float64() < expProbability {
-		(*config.Pokedex)[data.Name] = data
-		fmt.Println("Success! You successfully caught a", data.Name)
-	} else {
-		fmt.Println(data.Name, "escaped!")
+	nature, err := fetchNature(ctx, config, owned.Nature)
+	if err != nil {
+		return err
 	}
+	stats := computeStats(pokemonData, owned, nature)
 
-	return nil
-}
-
-func inspectPokemon(config *Config, pokemon string) error {
-	pokemonData, ok := (*config.Pokedex)[pokemon]
-	if !ok {
-		fmt.Println("you have not caught that pokemon yet")
-		return nil
+	fmt.Println("Name:", localName(ctx, config, "pokemon-species", pokemonData.Name))
+	if owned.Nickname != "" {
+		fmt.Println("Nickname:", owned.Nickname)
+	}
+	fmt.Println("ID:", owned.ID)
+	fmt.Println("Level:", owned.Level)
+	fmt.Println("Experience:", owned.Experience)
+	fmt.Println("Friendship:", owned.Friendship)
+	fmt.Println("Moves:", strings.Join(localNames(ctx, config, "move", owned.Moves), ", "))
+	fmt.Println("Nature:", pickName(config, "nature", nature.Name, nature.Names))
+	fmt.Println("Gender:", owned.Gender)
+	if owned.Shiny {
+		fmt.Println("Shiny: yes")
 	}
-	fmt.Println("Name:", pokemonData.Name)
 	fmt.Println("Height:", pokemonData.Height)
 	fmt.Println("Weight", pokemonData.Weight)
 	fmt.Println("Stats:")
-	for _, stat := range pokemonData.Stats {
-		fmt.Printf("  -%s: %d\n", stat.Stat.Name, stat.BaseStat)
+	for _, stat := range statNames {
+		fmt.Printf("  -%s: %d (IV %d, EV %d)\n", stat, stats[stat], owned.IVs[stat], owned.EVs[stat])
 	}
 
 	fmt.Println("Types:")
 	for _, ptype := range pokemonData.Types {
-		fmt.Printf("  - %s\n", ptype.Type.Name)
+		fmt.Printf("  - %s\n", localName(ctx, config, "type", ptype.Type.Name))
 	}
-
-	return nil
-}
-
-func getPokedex(config *Config) error {
-	myPokedex := (*config.Pokedex)
-	fmt.Println("Your Pokedex:")
-	for pokemon := range myPokedex {
-		fmt.Println("-", pokemon)
+	fmt.Println("Abilities:")
+	for _, ability := range pokemonData.Abilities {
+		fmt.Printf("  - %s\n", localName(ctx, config, "ability", ability.Ability.Name))
+	}
+	if len(owned.Tags) > 0 {
+		fmt.Println("Tags:", formatTags(owned.Tags))
+	}
+	if len(owned.Notes) > 0 {
+		fmt.Println("Notes:")
+		for _, note := range owned.Notes {
+			fmt.Printf("  - %s\n", note)
+		}
 	}
+
 	return nil
 }
 
@@ -539,13 +705,7 @@ func repl(commands map[string]cliCommand) {
 		input = strings.TrimSpace(input)
 		inputSlice := strings.Split(input, " ")
 		if command, ok := commands[inputSlice[0]]; ok {
-			var err error
-			if len(inputSlice) > 1 {
-				err = command.callback(inputSlice[1:]...)
-			} else {
-
-				err = command.callback()
-			}
+			err := runCommand(command, inputSlice[1:])
 			if err != nil {
 				fmt.Println("Error:", err)
 			}
@@ -555,56 +715,57 @@ func repl(commands map[string]cliCommand) {
 	}
 }
 
-func commandMap(config *Config) error {
-	var pokeData []byte
-	data := LocationAreaResponse{}
-	url := config.Next
-	if url == nil {
-		defaultURL := "https://pokeapi.co/api/v2/location-area/?limit=20"
-		url = &defaultURL
-	}
-	cacheEntry, ok := config.Cache.Get(*url)
-	if ok {
-		pokeData = cacheEntry
-	} else {
-		resp, err := http.Get(*url)
-		if err != nil {
-			return err
-		}
-
-		pokeData, err = io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
-		config.Cache.Add(*url, pokeData)
-	}
-
-	err := json.Unmarshal(pokeData, &data)
-	if err != nil {
-		return err
-	}
-	config.Next = &data.Next
-	config.Previous = &data.Previous
-	for _, area := range data.Results {
-		fmt.Println(area.Name)
-	}
-
-	return nil
+// runCommand executes a single command under its own deadline. Pressing
+// Ctrl+C while the command runs cancels it instead of exiting the Pokedex.
+func runCommand(command cliCommand, params []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+	return command.callback(ctx, params...)
 }
 
-func commandMapb(config *Config) error {
-	if config.Previous == nil || *config.Previous == "" {
-		fmt.Println("You are on the first page.")
-		return nil
-	}
-	config.Next = config.Previous
-	commandMap(config)
-	return nil
-}
 func main() {
+	savePath := flag.String("save", defaultSavePath(), "path of the save file")
+	freeMode := flag.Bool("free", false, "catch any pokemon anywhere without exploring first")
+	version := flag.String("version", "", "game version to play, such as red or platinum")
+	seed := flag.Int64("seed", 0, "seed for all randomness, 0 picks a random one")
+	flag.Parse()
+
 	cache := pokecache.NewCache(10 * time.Second)
 	pokedexMap := make(map[string]PokemonEndpoint)
-	config := Config{Cache: cache, Pokedex: &pokedexMap}
+	client := &http.Client{Timeout: requestTimeout}
+	rng, usedSeed := newRand(*seed)
+	config := Config{
+		BaseURL:   defaultBaseURL,
+		SavePath:  *savePath,
+		Map:       Pagination{Limit: defaultPageSize},
+		Explored:  make(map[string]bool),
+		Seen:      make(map[string]bool),
+		FreeMode:  *freeMode,
+		Seed:      usedSeed,
+		Rand:      rng,
+		Inventory: starterInventory(),
+		Money:     starterMoney,
+		Cache:     cache,
+		Pokedex:   &pokedexMap,
+		Owned:     make(map[int]*OwnedPokemon),
+		Client:    client,
+	}
+	err := loadGame(&config)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	if *version != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+		err = setVersion(ctx, &config, strings.ToLower(*version))
+		cancel()
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+	}
 	commands := getCommands(&config)
 	repl(commands)
 }
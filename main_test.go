@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edru2/pokedexcli/pokecache"
+)
+
+// newTestConfig returns a Config whose PokeAPI requests are served by
+// handler instead of the real API.
+func newTestConfig(t *testing.T, handler http.Handler) *Config {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	pokedexMap := make(map[string]PokemonEndpoint)
+	return &Config{
+		BaseURL:   server.URL,
+		Map:       Pagination{Limit: defaultPageSize},
+		Explored:  make(map[string]bool),
+		Seed:      1,
+		Rand:      rand.New(rand.NewSource(1)),
+		Inventory: starterInventory(),
+		Cache:     pokecache.NewCache(time.Minute),
+		Pokedex:   &pokedexMap,
+		Owned:     make(map[int]*OwnedPokemon),
+		Client:    server.Client(),
+	}
+}
+
+// fakeAPI serves each value JSON encoded at its path. Any "BASE" in the
+// encoded value is replaced by the server's own URL so that resources can
+// link to each other.
+type fakeAPI map[string]any
+
+func (f fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	value, ok := f[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	body, err := json.Marshal(value)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Write([]byte(strings.ReplaceAll(string(body), "BASE", "http://"+r.Host)))
+}
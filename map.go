@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const defaultPageSize = 20
+
+// Pagination tracks which page of location areas the map commands display.
+type Pagination struct {
+	Offset int  // offset of the page currently displayed
+	Limit  int  // number of areas per page
+	Count  int  // total number of areas, known after the first fetch
+	Shown  bool // whether a page has been displayed yet
+}
+
+func (p Pagination) pageNumber() int {
+	return p.Offset/p.Limit + 1
+}
+
+func (p Pagination) pageCount() int {
+	return (p.Count + p.Limit - 1) / p.Limit
+}
+
+func commandMap(ctx context.Context, config *Config, params ...string) error {
+	page := &config.Map
+	if len(params) == 0 {
+		if !page.Shown {
+			return showAreaPage(ctx, config, 0)
+		}
+		next := page.Offset + page.Limit
+		if next >= page.Count {
+			fmt.Println("You are on the last page.")
+			return nil
+		}
+		return showAreaPage(ctx, config, next)
+	}
+
+	switch params[0] {
+	case "first":
+		return showAreaPage(ctx, config, 0)
+	case "last":
+		if !page.Shown {
+			data, err := fetchAreaPage(ctx, config, 0)
+			if err != nil {
+				return err
+			}
+			page.Count = data.Count
+		}
+		if page.Count == 0 {
+			return errors.New("there are no location areas")
+		}
+		return showAreaPage(ctx, config, (page.Count-1)/page.Limit*page.Limit)
+	case "page":
+		n, err := parsePositive(params[1:])
+		if err != nil {
+			return err
+		}
+		offset := (n - 1) * page.Limit
+		if page.Shown && offset >= page.Count {
+			return fmt.Errorf("page %d does not exist, there are %d pages", n, page.pageCount())
+		}
+		return showAreaPage(ctx, config, offset)
+	case "size":
+		n, err := parsePositive(params[1:])
+		if err != nil {
+			return err
+		}
+		page.Limit = n
+		page.Offset = page.Offset / n * n
+		fmt.Println("Page size set to", n)
+		return nil
+	default:
+		return fmt.Errorf("unknown map option %q", params[0])
+	}
+}
+
+func commandMapb(ctx context.Context, config *Config) error {
+	page := &config.Map
+	if !page.Shown || page.Offset == 0 {
+		fmt.Println("You are on the first page.")
+		return nil
+	}
+	return showAreaPage(ctx, config, max(page.Offset-page.Limit, 0))
+}
+
+// showAreaPage prints the page of location areas starting at offset and
+// only then records it as the current page, so a failed fetch leaves the
+// pagination state untouched.
+func showAreaPage(ctx context.Context, config *Config, offset int) error {
+	data, err := fetchAreaPage(ctx, config, offset)
+	if err != nil {
+		return err
+	}
+	if len(data.Results) == 0 {
+		return errors.New("no location areas on that page")
+	}
+
+	page := &config.Map
+	page.Offset = offset
+	page.Count = data.Count
+	page.Shown = true
+	for _, area := range data.Results {
+		fmt.Println(localName(ctx, config, "location-area", area.Name))
+	}
+	fmt.Printf("Page %d of %d\n", page.pageNumber(), page.pageCount())
+	return nil
+}
+
+func fetchAreaPage(ctx context.Context, config *Config, offset int) (LocationAreaResponse, error) {
+	data := LocationAreaResponse{}
+	url := fmt.Sprintf("%s/location-area/?offset=%d&limit=%d", config.BaseURL, offset, config.Map.Limit)
+	err := fetchJSON(ctx, config, url, &data)
+	return data, err
+}
+
+// parsePositive reads the single positive integer argument of a subcommand.
+func parsePositive(params []string) (int, error) {
+	if len(params) == 0 {
+		return 0, errors.New("missing number")
+	}
+	n, err := strconv.Atoi(params[0])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%q is not a positive number", params[0])
+	}
+	return n, nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// fakeAreas serves count location areas named area-0, area-1, ... and
+// records the offset of every request it receives.
+type fakeAreas struct {
+	count   int
+	offsets []int
+}
+
+func (f *fakeAreas) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/location-area/" {
+		http.NotFound(w, r)
+		return
+	}
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	f.offsets = append(f.offsets, offset)
+
+	data := LocationAreaResponse{Count: f.count}
+	for i := offset; i < offset+limit && i < f.count; i++ {
+		data.Results = append(data.Results, struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		}{Name: fmt.Sprintf("area-%d", i)})
+	}
+	json.NewEncoder(w).Encode(data)
+}
+
+func newMapConfig(t *testing.T, count, limit int) (*Config, *fakeAreas) {
+	t.Helper()
+	fake := &fakeAreas{count: count}
+	config := newTestConfig(t, fake)
+	config.Map.Limit = limit
+	return config, fake
+}
+
+func TestMapPagination(t *testing.T) {
+	config, fake := newMapConfig(t, 5, 2)
+	ctx := context.Background()
+
+	steps := []struct {
+		command string
+		offset  int
+	}{
+		{"map", 0},
+		{"map", 2},
+		{"map", 4},
+		{"map", 4},
+		{"mapb", 2},
+		{"mapb", 0},
+		{"mapb", 0},
+		{"map", 2},
+	}
+	for i, step := range steps {
+		var err error
+		if step.command == "map" {
+			err = commandMap(ctx, config)
+		} else {
+			err = commandMapb(ctx, config)
+		}
+		if err != nil {
+			t.Fatalf("step %d (%s): unexpected error: %v", i, step.command, err)
+		}
+		if config.Map.Offset != step.offset {
+			t.Errorf("step %d (%s): expected offset %d, got %d", i, step.command, step.offset, config.Map.Offset)
+		}
+	}
+
+	for _, offset := range fake.offsets {
+		if offset >= fake.count {
+			t.Errorf("requested a page past the end at offset %d", offset)
+		}
+	}
+}
+
+func TestMapPageJumping(t *testing.T) {
+	config, _ := newMapConfig(t, 5, 2)
+	ctx := context.Background()
+
+	cases := []struct {
+		params []string
+		offset int
+	}{
+		{[]string{"last"}, 4},
+		{[]string{"first"}, 0},
+		{[]string{"page", "2"}, 2},
+		{[]string{"page", "3"}, 4},
+	}
+	for _, c := range cases {
+		if err := commandMap(ctx, config, c.params...); err != nil {
+			t.Fatalf("map %v: unexpected error: %v", c.params, err)
+		}
+		if config.Map.Offset != c.offset {
+			t.Errorf("map %v: expected offset %d, got %d", c.params, c.offset, config.Map.Offset)
+		}
+	}
+
+	for _, params := range [][]string{{"page", "4"}, {"page", "0"}, {"page"}, {"nowhere"}} {
+		if err := commandMap(ctx, config, params...); err == nil {
+			t.Errorf("map %v: expected an error", params)
+		}
+		if config.Map.Offset != 4 {
+			t.Errorf("map %v: expected offset to stay at 4, got %d", params, config.Map.Offset)
+		}
+	}
+}
+
+func TestMapPageSize(t *testing.T) {
+	config, fake := newMapConfig(t, 10, 2)
+	ctx := context.Background()
+
+	if err := commandMap(ctx, config, "page", "4"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := commandMap(ctx, config, "size", "4"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Map.Offset != 4 {
+		t.Errorf("expected offset to be realigned to 4, got %d", config.Map.Offset)
+	}
+	if err := commandMap(ctx, config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Map.Offset != 8 {
+		t.Errorf("expected offset 8, got %d", config.Map.Offset)
+	}
+	if last := fake.offsets[len(fake.offsets)-1]; last != 8 {
+		t.Errorf("expected request at offset 8, got %d", last)
+	}
+}
+
+func TestMapFetchError(t *testing.T) {
+	config := newTestConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+
+	if err := commandMap(context.Background(), config); err == nil {
+		t.Fatal("expected an error")
+	}
+	if config.Map.Shown {
+		t.Error("expected pagination state to be untouched after a failed fetch")
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxKnownMoves is how many moves a Pokemon can know at once.
+const maxKnownMoves = 4
+
+type MoveEndpoint struct {
+	ID          int              `json:"id"`
+	Name        string           `json:"name"`
+	Names       []Name           `json:"names"`
+	Accuracy    int              `json:"accuracy"`
+	Power       int              `json:"power"`
+	PP          int              `json:"pp"`
+	Priority    int              `json:"priority"`
+	Type        NamedAPIResource `json:"type"`
+	DamageClass NamedAPIResource `json:"damage_class"`
+}
+
+func fetchMove(ctx context.Context, config *Config, move string) (MoveEndpoint, error) {
+	data := MoveEndpoint{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/move/%s/", config.BaseURL, move), &data)
+	return data, err
+}
+
+// levelUpMove is a move a Pokemon learns by leveling up.
+type levelUpMove struct {
+	Move  string
+	Level int
+}
+
+// levelUpMoves lists the moves pokemon learns by leveling up to level in
+// versionGroup, ordered by level. With no version group, every group
+// counts and a move is learned at the lowest level any of them teaches it.
+func levelUpMoves(pokemon PokemonEndpoint, versionGroup string, level int) []levelUpMove {
+	var moves []levelUpMove
+	for _, move := range pokemon.Moves {
+		learnedAt := -1
+		for _, detail := range move.VersionGroupDetails {
+			if detail.MoveLearnMethod.Name != "level-up" || detail.LevelLearnedAt > level {
+				continue
+			}
+			if versionGroup != "" && detail.VersionGroup.Name != versionGroup {
+				continue
+			}
+			if learnedAt < 0 || detail.LevelLearnedAt < learnedAt {
+				learnedAt = detail.LevelLearnedAt
+			}
+		}
+		if learnedAt >= 0 {
+			moves = append(moves, levelUpMove{Move: move.Move.Name, Level: learnedAt})
+		}
+	}
+	sort.SliceStable(moves, func(i, j int) bool { return moves[i].Level < moves[j].Level })
+	return moves
+}
+
+// startingMoves returns the moves a Pokemon of the given level knows: the
+// last four it learned by leveling up.
+func startingMoves(pokemon PokemonEndpoint, versionGroup string, level int) []string {
+	learned := levelUpMoves(pokemon, versionGroup, level)
+	if len(learned) > maxKnownMoves {
+		learned = learned[len(learned)-maxKnownMoves:]
+	}
+	moves := make([]string, 0, len(learned))
+	for _, move := range learned {
+		moves = append(moves, move.Move)
+	}
+	return moves
+}
+
+// learnableMove is a move a Pokemon can learn by one method, with the
+// level it learns it at for the level-up method.
+type learnableMove struct {
+	Move   string
+	Method string
+	Level  int
+}
+
+// learnableMoves lists the moves pokemon can learn by method in
+// versionGroup, where an empty method or version group matches all of
+// them. Level-up moves come first, ordered by level.
+func learnableMoves(pokemon PokemonEndpoint, method, versionGroup string) []learnableMove {
+	var moves []learnableMove
+	for _, move := range pokemon.Moves {
+		byMethod := make(map[string]int)
+		var methods []string
+		for _, detail := range move.VersionGroupDetails {
+			if method != "" && detail.MoveLearnMethod.Name != method {
+				continue
+			}
+			if versionGroup != "" && detail.VersionGroup.Name != versionGroup {
+				continue
+			}
+			name := detail.MoveLearnMethod.Name
+			level, ok := byMethod[name]
+			if !ok {
+				methods = append(methods, name)
+			}
+			if !ok || detail.LevelLearnedAt < level {
+				byMethod[name] = detail.LevelLearnedAt
+			}
+		}
+		for _, name := range methods {
+			moves = append(moves, learnableMove{Move: move.Move.Name, Method: name, Level: byMethod[name]})
+		}
+	}
+	sort.SliceStable(moves, func(i, j int) bool {
+		a, b := moves[i], moves[j]
+		if (a.Method == "level-up") != (b.Method == "level-up") {
+			return a.Method == "level-up"
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		if a.Level != b.Level {
+			return a.Level < b.Level
+		}
+		return a.Move < b.Move
+	})
+	return moves
+}
+
+func commandMoves(ctx context.Context, config *Config, params ...string) error {
+	pokemon, method, versionGroup := "", "", ""
+	for i := 0; i < len(params); i++ {
+		if params[i] == "--method" || params[i] == "--version-group" {
+			if i+1 >= len(params) {
+				return fmt.Errorf("missing value for %s", params[i])
+			}
+			if params[i] == "--method" {
+				method = strings.ToLower(params[i+1])
+			} else {
+				versionGroup = strings.ToLower(params[i+1])
+			}
+			i++
+			continue
+		}
+		pokemon = params[i]
+	}
+	if pokemon == "" {
+		return errors.New("use moves <pokemon> [--method level-up|machine|egg|tutor] [--version-group <group>]")
+	}
+	if versionGroup == "" {
+		var err error
+		versionGroup, err = currentVersionGroup(ctx, config)
+		if err != nil {
+			return err
+		}
+	}
+	data, err := fetchPokemon(ctx, config, resolveSpecies(ctx, config, pokemon))
+	if err != nil {
+		return err
+	}
+
+	moves := learnableMoves(data, method, versionGroup)
+	if len(moves) == 0 {
+		fmt.Println("No matching moves.")
+		return nil
+	}
+	details, err := fetchMoves(ctx, config, moves)
+	if err != nil {
+		return err
+	}
+	for _, learnable := range moves {
+		move := details[learnable.Move]
+		learned := learnable.Method
+		if learnable.Method == "level-up" {
+			learned = fmt.Sprintf("level %d", learnable.Level)
+		}
+		fmt.Printf("- %s [%s] (%s, power %s, accuracy %s, pp %d)\n", pickName(config, "move", move.Name, move.Names), learned,
+			localName(ctx, config, "type", move.Type.Name), statOrDash(move.Power), statOrDash(move.Accuracy), move.PP)
+	}
+	return nil
+}
+
+// fetchMoves fetches every move of a learnset once, a few at a time, as
+// Pokemon such as Mew learn hundreds of them.
+func fetchMoves(ctx context.Context, config *Config, moves []learnableMove) (map[string]MoveEndpoint, error) {
+	seen := make(map[string]bool)
+	var names []string
+	for _, learnable := range moves {
+		if !seen[learnable.Move] {
+			seen[learnable.Move] = true
+			names = append(names, learnable.Move)
+		}
+	}
+	fetched := make([]MoveEndpoint, len(names))
+	err := fetchEach(len(names), func(i int) error {
+		var err error
+		fetched[i], err = fetchMove(ctx, config, names[i])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	details := make(map[string]MoveEndpoint, len(names))
+	for i, name := range names {
+		details[name] = fetched[i]
+	}
+	return details, nil
+}
+
+// statOrDash formats the power or accuracy of a move, which status moves
+// and moves that never miss have none of.
+func statOrDash(value int) string {
+	if value == 0 {
+		return "-"
+	}
+	return strconv.Itoa(value)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestLearnableMoves(t *testing.T) {
+	pokemon := PokemonEndpoint{}
+	err := json.Unmarshal([]byte(`{"moves": [
+		{"move": {"name": "thunderbolt"}, "version_group_details": [
+			{"level_learned_at": 26, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}},
+			{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "red-blue"}},
+			{"level_learned_at": 29, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "yellow"}}
+		]},
+		{"move": {"name": "thunder-shock"}, "version_group_details": [
+			{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}}
+		]},
+		{"move": {"name": "volt-tackle"}, "version_group_details": [
+			{"level_learned_at": 0, "move_learn_method": {"name": "egg"}, "version_group": {"name": "emerald"}}
+		]}
+	]}`), &pokemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		method       string
+		versionGroup string
+		expected     []learnableMove
+	}{
+		{"", "red-blue", []learnableMove{
+			{Move: "thunder-shock", Method: "level-up", Level: 1},
+			{Move: "thunderbolt", Method: "level-up", Level: 26},
+			{Move: "thunderbolt", Method: "machine"},
+		}},
+		{"level-up", "", []learnableMove{
+			{Move: "thunder-shock", Method: "level-up", Level: 1},
+			{Move: "thunderbolt", Method: "level-up", Level: 26},
+		}},
+		{"egg", "", []learnableMove{{Move: "volt-tackle", Method: "egg"}}},
+		{"tutor", "", nil},
+	}
+	for _, c := range cases {
+		got := learnableMoves(pokemon, c.method, c.versionGroup)
+		if !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("method %q, version group %q: expected %v, got %v", c.method, c.versionGroup, c.expected, got)
+		}
+	}
+}
+
+func TestFetchMoves(t *testing.T) {
+	config := newTestConfig(t, fakeAPI{
+		"/move/tackle/":        MoveEndpoint{Name: "tackle", Power: 40},
+		"/move/thunder-shock/": MoveEndpoint{Name: "thunder-shock", Power: 40},
+	})
+	ctx := context.Background()
+
+	details, err := fetchMoves(ctx, config, []learnableMove{
+		{Move: "tackle", Method: "level-up", Level: 1},
+		{Move: "thunder-shock", Method: "level-up", Level: 1},
+		{Move: "tackle", Method: "egg"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(details) != 2 || details["tackle"].Name != "tackle" || details["thunder-shock"].Name != "thunder-shock" {
+		t.Errorf("expected tackle and thunder-shock, got %v", details)
+	}
+	if _, err := fetchMoves(ctx, config, []learnableMove{{Move: "splash"}}); err == nil {
+		t.Error("expected an error for an unknown move")
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func commandNickname(ctx context.Context, config *Config, params ...string) error {
+	if len(params) < 2 {
+		return errors.New("use nickname <pokemon> <name>")
+	}
+	// Commands take a nickname as a single argument and read numbers as
+	// IDs, so nicknames must be one word that is not a number.
+	if len(params) > 2 {
+		return errors.New("nicknames can't contain spaces")
+	}
+	if _, err := strconv.Atoi(params[1]); err == nil {
+		return errors.New("nicknames can't be numbers, they would be mistaken for IDs")
+	}
+	owned, err := findOwned(config, params[0])
+	if err != nil {
+		return err
+	}
+	if err := checkNickname(config, owned, params[1]); err != nil {
+		return err
+	}
+	owned.Nickname = params[1]
+	fmt.Printf("#%d %s is now called %s.\n", owned.ID, localName(ctx, config, "pokemon-species", owned.Species), owned.Nickname)
+	return nil
+}
+
+// checkNickname rejects nicknames that findOwned could confuse with
+// another Pokemon: one another Pokemon already goes by, or the name of a
+// species the trainer knows.
+func checkNickname(config *Config, owned *OwnedPokemon, nickname string) error {
+	for _, other := range config.Owned {
+		if other != owned && strings.EqualFold(other.Nickname, nickname) {
+			return fmt.Errorf("#%d is already called %s", other.ID, other.Nickname)
+		}
+	}
+	species := resolveName(config, "pokemon-species", nickname)
+	if species != nickname || containsString(knownSpecies(config), strings.ToLower(nickname)) {
+		return fmt.Errorf("%s is the name of a species, pick another nickname", nickname)
+	}
+	return nil
+}
+
+func commandNote(ctx context.Context, config *Config, params ...string) error {
+	if len(params) < 2 {
+		return errors.New("use note <pokemon> <text>")
+	}
+	owned, err := findOwned(config, params[0])
+	if err != nil {
+		return err
+	}
+	owned.Notes = append(owned.Notes, strings.Join(params[1:], " "))
+	fmt.Printf("Note added to %s.\n", describeOwned(ctx, config, owned))
+	return nil
+}
+
+func commandTag(ctx context.Context, config *Config, params ...string) error {
+	if len(params) < 2 {
+		return errors.New("use tag <pokemon> <tag>")
+	}
+	owned, err := findOwned(config, params[0])
+	if err != nil {
+		return err
+	}
+	tag := strings.ToLower(params[1])
+	if owned.hasTag(tag) {
+		return fmt.Errorf("%s is already tagged %s", describeOwned(ctx, config, owned), tag)
+	}
+	owned.Tags = append(owned.Tags, tag)
+	fmt.Printf("Tagged %s as %s.\n", describeOwned(ctx, config, owned), tag)
+	return nil
+}
+
+func (owned *OwnedPokemon) hasTag(tag string) bool {
+	for _, t := range owned.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ownedName returns the nickname of a Pokemon, or its localized species
+// without one.
+func ownedName(ctx context.Context, config *Config, owned *OwnedPokemon) string {
+	if owned.Nickname != "" {
+		return owned.Nickname
+	}
+	return localName(ctx, config, "pokemon-species", owned.Species)
+}
+
+func formatTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(tags, ", ") + "]"
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestNicknameNoteTag(t *testing.T) {
+	config := newPartyConfig(2)
+	ctx := context.Background()
+
+	if err := commandNickname(ctx, config, "2", "Sky", "King"); err == nil {
+		t.Error("expected an error for a nickname with spaces")
+	}
+	if err := commandNickname(ctx, config, "2", "1"); err == nil {
+		t.Error("expected an error for a nickname that looks like an ID")
+	}
+	if err := commandNickname(ctx, config, "2", "SkyKing"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := commandNickname(ctx, config, "1", "skyking"); err == nil {
+		t.Error("expected an error for a nickname another pokemon goes by")
+	}
+	markSeen(config, "rattata")
+	if err := commandNickname(ctx, config, "1", "Rattata"); err == nil {
+		t.Error("expected an error for a nickname that is a species name")
+	}
+	owned, err := findOwned(config, "skyking")
+	if err != nil || owned.ID != 2 {
+		t.Fatalf("expected to find #2 by nickname, got %v, %v", owned, err)
+	}
+
+	if err := commandNote(ctx, config, "SkyKing", "caught", "on", "route", "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(owned.Notes, []string{"caught on route 1"}) {
+		t.Errorf("unexpected notes %v", owned.Notes)
+	}
+
+	if err := commandTag(ctx, config, "2", "Shiny-Hunt"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := commandTag(ctx, config, "2", "shiny-hunt"); err == nil {
+		t.Error("expected an error adding the same tag twice")
+	}
+	if !owned.hasTag("shiny-hunt") || config.Owned[1].hasTag("shiny-hunt") {
+		t.Errorf("expected only #2 to be tagged, got %v and %v", owned.Tags, config.Owned[1].Tags)
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// statNames lists the PokeAPI stat names in the order the games show them.
+var statNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+const (
+	// defaultCatchLevel is the level of a Pokemon caught by name rather
+	// than from an encounter.
+	defaultCatchLevel = 5
+	maxIV             = 31
+	// shinyOdds is the 1 in n chance for a Pokemon to be shiny.
+	shinyOdds = 4096
+)
+
+type NamedAPIResourceList struct {
+	Count   int                `json:"count"`
+	Results []NamedAPIResource `json:"results"`
+}
+
+type NatureEndpoint struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	Names         []Name           `json:"names"`
+	IncreasedStat NamedAPIResource `json:"increased_stat"`
+	DecreasedStat NamedAPIResource `json:"decreased_stat"`
+}
+
+// OwnedPokemon is a single Pokemon the trainer caught. Several of them can
+// share a species.
+type OwnedPokemon struct {
+	ID         int            `json:"id"`
+	Species    string         `json:"species"`
+	Level      int            `json:"level"`
+	Experience int            `json:"experience"`
+	Friendship int            `json:"friendship"`
+	IVs        map[string]int `json:"ivs"`
+	EVs        map[string]int `json:"evs"`
+	Nature     string         `json:"nature"`
+	Gender     string         `json:"gender"`
+	Shiny      bool           `json:"shiny"`
+	CaughtAt   time.Time      `json:"caught_at"`
+	Damage     int            `json:"damage,omitempty"`
+	Moves      []string       `json:"moves"`
+	Nickname   string         `json:"nickname,omitempty"`
+	Notes      []string       `json:"notes,omitempty"`
+	Tags       []string       `json:"tags,omitempty"`
+}
+
+// newOwnedPokemon rolls the individual traits of a freshly caught Pokemon
+// and adds it to the trainer's Pokemon.
+func newOwnedPokemon(ctx context.Context, config *Config, pokemon PokemonEndpoint, species PokemonSpeciesEndpoint, level int) (*OwnedPokemon, error) {
+	natures := NamedAPIResourceList{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/nature/?limit=25", config.BaseURL), &natures)
+	if err != nil {
+		return nil, err
+	}
+	if len(natures.Results) == 0 {
+		return nil, fmt.Errorf("no natures found")
+	}
+	versionGroup, err := currentVersionGroup(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	growth, err := fetchGrowthRate(ctx, config, species)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := &OwnedPokemon{
+		ID:         nextOwnedID(config),
+		Species:    pokemon.Name,
+		Level:      level,
+		Experience: growth.experienceAt(level),
+		IVs:        make(map[string]int),
+		EVs:        make(map[string]int),
+		Nature:     natures.Results[config.Rand.Intn(len(natures.Results))].Name,
+		Gender:     rollGender(config, species.GenderRate),
+		Friendship: species.BaseHappiness,
+		Shiny:      config.Rand.Intn(shinyOdds) == 0,
+		CaughtAt:   time.Now(),
+		Moves:      startingMoves(pokemon, versionGroup, level),
+	}
+	for _, stat := range statNames {
+		owned.IVs[stat] = config.Rand.Intn(maxIV + 1)
+		owned.EVs[stat] = 0
+	}
+	config.Owned[owned.ID] = owned
+	return owned, nil
+}
+
+func nextOwnedID(config *Config) int {
+	next := 1
+	for id := range config.Owned {
+		next = max(next, id+1)
+	}
+	return next
+}
+
+// rollGender picks a gender from the species gender rate, the chance of
+// being female in eighths, or -1 for genderless species.
+func rollGender(config *Config, genderRate int) string {
+	switch {
+	case genderRate < 0:
+		return "genderless"
+	case config.Rand.Intn(8) < genderRate:
+		return "female"
+	default:
+		return "male"
+	}
+}
+
+// computeStats applies the standard stat formulas to the base stats of
+// pokemon and the individual values of owned. Neutral natures raise and
+// lower the same stat, which leaves it as it is.
+func computeStats(pokemon PokemonEndpoint, owned *OwnedPokemon, nature NatureEndpoint) map[string]int {
+	stats := make(map[string]int)
+	for _, stat := range pokemon.Stats {
+		name := stat.Stat.Name
+		core := (2*stat.BaseStat + owned.IVs[name] + owned.EVs[name]/4) * owned.Level / 100
+		if name == "hp" {
+			stats[name] = core + owned.Level + 10
+			continue
+		}
+		value := core + 5
+		switch {
+		case nature.IncreasedStat.Name == nature.DecreasedStat.Name:
+		case name == nature.IncreasedStat.Name:
+			value = value * 110 / 100
+		case name == nature.DecreasedStat.Name:
+			value = value * 90 / 100
+		}
+		stats[name] = value
+	}
+	return stats
+}
+
+func fetchNature(ctx context.Context, config *Config, nature string) (NatureEndpoint, error) {
+	data := NatureEndpoint{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/nature/%s/", config.BaseURL, nature), &data)
+	return data, err
+}
+
+// findOwned looks a Pokemon up by ID, nickname or species name. A name is
+// only accepted when exactly one Pokemon goes by it, as a nickname or as
+// its species.
+func findOwned(config *Config, pokemon string) (*OwnedPokemon, error) {
+	if id, err := strconv.Atoi(pokemon); err == nil {
+		owned, ok := config.Owned[id]
+		if !ok {
+			return nil, fmt.Errorf("you have no pokemon with ID %d", id)
+		}
+		return owned, nil
+	}
+
+	species := resolveName(config, "pokemon-species", pokemon)
+	var matches []*OwnedPokemon
+	for _, id := range sortedOwnedIDs(config) {
+		owned := config.Owned[id]
+		if strings.EqualFold(owned.Nickname, pokemon) || owned.Species == species {
+			matches = append(matches, owned)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("you have not caught a %s yet", pokemon)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := ""
+		for _, owned := range matches {
+			ids += fmt.Sprintf(" %d", owned.ID)
+		}
+		return nil, fmt.Errorf("you have several %s, use one of their IDs:%s", pokemon, ids)
+	}
+}
+
+func sortedOwnedIDs(config *Config) []int {
+	ids := make([]int, 0, len(config.Owned))
+	for id := range config.Owned {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// ownedOfSpecies returns the owned Pokemon of a species by ascending ID.
+func ownedOfSpecies(config *Config, species string) []*OwnedPokemon {
+	var matches []*OwnedPokemon
+	for _, owned := range config.Owned {
+		if owned.Species == species {
+			matches = append(matches, owned)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+	return matches
+}
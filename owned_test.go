@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestComputeStats(t *testing.T) {
+	// The level 78 Adamant Garchomp from Bulbapedia's stat article.
+	garchomp := PokemonEndpoint{}
+	err := json.Unmarshal([]byte(`{"name": "garchomp", "stats": [
+		{"base_stat": 108, "stat": {"name": "hp"}},
+		{"base_stat": 130, "stat": {"name": "attack"}},
+		{"base_stat": 95, "stat": {"name": "defense"}},
+		{"base_stat": 80, "stat": {"name": "special-attack"}},
+		{"base_stat": 85, "stat": {"name": "special-defense"}},
+		{"base_stat": 102, "stat": {"name": "speed"}}
+	]}`), &garchomp)
+	if err != nil {
+		t.Fatalf("decoding garchomp: %v", err)
+	}
+	owned := &OwnedPokemon{
+		Species: "garchomp",
+		Level:   78,
+		IVs:     map[string]int{"hp": 24, "attack": 12, "defense": 30, "special-attack": 16, "special-defense": 23, "speed": 5},
+		EVs:     map[string]int{"hp": 74, "attack": 190, "defense": 91, "special-attack": 48, "special-defense": 84, "speed": 23},
+	}
+	adamant := NatureEndpoint{
+		Name:          "adamant",
+		IncreasedStat: NamedAPIResource{Name: "attack"},
+		DecreasedStat: NamedAPIResource{Name: "special-attack"},
+	}
+
+	// Neutral natures such as Hardy raise and lower the same stat.
+	hardy := NatureEndpoint{
+		Name:          "hardy",
+		IncreasedStat: NamedAPIResource{Name: "attack"},
+		DecreasedStat: NamedAPIResource{Name: "attack"},
+	}
+
+	cases := []struct {
+		nature   NatureEndpoint
+		expected map[string]int
+	}{
+		{adamant, map[string]int{"hp": 289, "attack": 278, "defense": 193, "special-attack": 135, "special-defense": 171, "speed": 171}},
+		{hardy, map[string]int{"hp": 289, "attack": 253, "defense": 193, "special-attack": 151, "special-defense": 171, "speed": 171}},
+	}
+	for _, c := range cases {
+		stats := computeStats(garchomp, owned, c.nature)
+		for _, stat := range statNames {
+			if stats[stat] != c.expected[stat] {
+				t.Errorf("%s %s: expected %d, got %d", c.nature.Name, stat, c.expected[stat], stats[stat])
+			}
+		}
+	}
+}
+
+func TestFindOwned(t *testing.T) {
+	config := &Config{Owned: map[int]*OwnedPokemon{
+		1: {ID: 1, Species: "pidgey"},
+		2: {ID: 2, Species: "pidgey"},
+		3: {ID: 3, Species: "rattata"},
+	}}
+
+	if owned, err := findOwned(config, "rattata"); err != nil || owned.ID != 3 {
+		t.Errorf("expected rattata to be found by name, got %v, %v", owned, err)
+	}
+	if owned, err := findOwned(config, "2"); err != nil || owned.ID != 2 {
+		t.Errorf("expected pidgey 2 to be found by ID, got %v, %v", owned, err)
+	}
+	for _, pokemon := range []string{"pidgey", "4", "mew"} {
+		if _, err := findOwned(config, pokemon); err == nil {
+			t.Errorf("expected an error finding %q", pokemon)
+		}
+	}
+
+	// Nicknames from before they were checked can still clash with a
+	// species, which is ambiguous rather than the nicknamed Pokemon.
+	config.Owned[3].Nickname = "pidgey"
+	config.Owned[1].Species = "spearow"
+	if _, err := findOwned(config, "pidgey"); err == nil {
+		t.Error("expected an error for a nickname that is also an owned species")
+	}
+	if owned, err := findOwned(config, "spearow"); err != nil || owned.ID != 1 {
+		t.Errorf("expected spearow to be found by name, got %v, %v", owned, err)
+	}
+	if next := nextOwnedID(config); next != 4 {
+		t.Errorf("expected next ID 4, got %d", next)
+	}
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const (
+	partySize   = 6
+	boxCapacity = 30
+)
+
+// organizePokemon makes sure every owned Pokemon is in exactly one place,
+// the party or a box, dropping IDs that are no longer owned and storing
+// Pokemon that are nowhere, such as those from saves made before boxes.
+func organizePokemon(config *Config) {
+	placed := make(map[int]bool)
+	keep := func(ids []int) []int {
+		kept := []int{}
+		for _, id := range ids {
+			if _, ok := config.Owned[id]; ok && !placed[id] {
+				placed[id] = true
+				kept = append(kept, id)
+			}
+		}
+		return kept
+	}
+	config.Party = keep(config.Party)
+	for i := range config.Boxes {
+		config.Boxes[i] = keep(config.Boxes[i])
+	}
+
+	for _, id := range sortedOwnedIDs(config) {
+		if !placed[id] {
+			storePokemon(config, id)
+		}
+	}
+}
+
+// storePokemon puts a newly caught Pokemon in the party, or in the first box
+// with room once the party is full. It returns where it went.
+func storePokemon(config *Config, id int) string {
+	if len(config.Party) < partySize {
+		config.Party = append(config.Party, id)
+		return "your party"
+	}
+	box := firstBoxWithRoom(config)
+	config.Boxes[box] = append(config.Boxes[box], id)
+	return fmt.Sprintf("box %d", box+1)
+}
+
+// firstBoxWithRoom returns the index of the first box that is not full,
+// adding a box when all of them are.
+func firstBoxWithRoom(config *Config) int {
+	for i, box := range config.Boxes {
+		if len(box) < boxCapacity {
+			return i
+		}
+	}
+	config.Boxes = append(config.Boxes, []int{})
+	return len(config.Boxes) - 1
+}
+
+// locatePokemon returns the slice holding id and its index there. box is -1
+// for the party.
+func locatePokemon(config *Config, id int) (box, index int, err error) {
+	for i, partyID := range config.Party {
+		if partyID == id {
+			return -1, i, nil
+		}
+	}
+	for b, ids := range config.Boxes {
+		for i, boxID := range ids {
+			if boxID == id {
+				return b, i, nil
+			}
+		}
+	}
+	return 0, 0, fmt.Errorf("you have no pokemon with ID %d", id)
+}
+
+func removeAt(ids []int, index int) []int {
+	return append(ids[:index:index], ids[index+1:]...)
+}
+
+// takePokemon removes id from wherever it is.
+func takePokemon(config *Config, id int) error {
+	box, index, err := locatePokemon(config, id)
+	if err != nil {
+		return err
+	}
+	if box < 0 {
+		config.Party = removeAt(config.Party, index)
+	} else {
+		config.Boxes[box] = removeAt(config.Boxes[box], index)
+	}
+	return nil
+}
+
+func parseID(params []string) (int, error) {
+	if len(params) == 0 {
+		return 0, errors.New("missing pokemon ID")
+	}
+	id, err := strconv.Atoi(params[0])
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a pokemon ID", params[0])
+	}
+	return id, nil
+}
+
+func describeOwned(ctx context.Context, config *Config, owned *OwnedPokemon) string {
+	species := localName(ctx, config, "pokemon-species", owned.Species)
+	if owned.Nickname != "" {
+		return fmt.Sprintf("#%d %s (%s) Lv. %d", owned.ID, owned.Nickname, species, owned.Level)
+	}
+	return fmt.Sprintf("#%d %s Lv. %d", owned.ID, species, owned.Level)
+}
+
+func commandParty(ctx context.Context, config *Config, params ...string) error {
+	if len(params) == 0 {
+		if len(config.Party) == 0 {
+			fmt.Println("Your party is empty.")
+			return nil
+		}
+		fmt.Println("Your party:")
+		for i, id := range config.Party {
+			fmt.Printf("%d. %s\n", i+1, describeOwned(ctx, config, config.Owned[id]))
+		}
+		return nil
+	}
+	if config.Battle != nil {
+		return errInBattle
+	}
+
+	switch params[0] {
+	case "add":
+		id, err := parseID(params[1:])
+		if err != nil {
+			return err
+		}
+		box, _, err := locatePokemon(config, id)
+		if err != nil {
+			return err
+		}
+		if box < 0 {
+			return fmt.Errorf("#%d is already in your party", id)
+		}
+		if len(config.Party) >= partySize {
+			return errors.New("your party is full, remove or swap a pokemon first")
+		}
+		takePokemon(config, id)
+		config.Party = append(config.Party, id)
+		fmt.Printf("%s joined your party.\n", describeOwned(ctx, config, config.Owned[id]))
+		return nil
+	case "remove":
+		id, err := parseID(params[1:])
+		if err != nil {
+			return err
+		}
+		box, _, err := locatePokemon(config, id)
+		if err != nil {
+			return err
+		}
+		if box >= 0 {
+			return fmt.Errorf("#%d is not in your party", id)
+		}
+		takePokemon(config, id)
+		box = firstBoxWithRoom(config)
+		config.Boxes[box] = append(config.Boxes[box], id)
+		fmt.Printf("%s was sent to box %d.\n", describeOwned(ctx, config, config.Owned[id]), box+1)
+		return nil
+	case "swap":
+		if len(params) < 3 {
+			return errors.New("use party swap <id> <id>")
+		}
+		a, err := parseID(params[1:2])
+		if err != nil {
+			return err
+		}
+		b, err := parseID(params[2:3])
+		if err != nil {
+			return err
+		}
+		return swapPokemon(config, a, b)
+	default:
+		return fmt.Errorf("unknown party option %q", params[0])
+	}
+}
+
+// swapPokemon exchanges the places of two Pokemon, which reorders the party
+// or trades a party member for a boxed one.
+func swapPokemon(config *Config, a, b int) error {
+	boxA, indexA, err := locatePokemon(config, a)
+	if err != nil {
+		return err
+	}
+	boxB, indexB, err := locatePokemon(config, b)
+	if err != nil {
+		return err
+	}
+	slot := func(box, index int) *int {
+		if box < 0 {
+			return &config.Party[index]
+		}
+		return &config.Boxes[box][index]
+	}
+	slotA, slotB := slot(boxA, indexA), slot(boxB, indexB)
+	*slotA, *slotB = *slotB, *slotA
+	fmt.Printf("Swapped #%d and #%d.\n", a, b)
+	return nil
+}
+
+func commandBox(ctx context.Context, config *Config, params ...string) error {
+	if len(params) == 0 {
+		return errors.New("use box list, box view <n> or box move <id> <n>")
+	}
+	if params[0] == "move" && config.Battle != nil {
+		return errInBattle
+	}
+
+	switch params[0] {
+	case "list":
+		if len(config.Boxes) == 0 {
+			fmt.Println("Your PC boxes are empty.")
+			return nil
+		}
+		for i, box := range config.Boxes {
+			fmt.Printf("Box %d: %d/%d\n", i+1, len(box), boxCapacity)
+		}
+		return nil
+	case "view":
+		n, err := parseBox(config, params[1:], false)
+		if err != nil {
+			return err
+		}
+		if len(config.Boxes[n]) == 0 {
+			fmt.Printf("Box %d is empty.\n", n+1)
+			return nil
+		}
+		fmt.Printf("Box %d:\n", n+1)
+		for _, id := range config.Boxes[n] {
+			fmt.Println("-", describeOwned(ctx, config, config.Owned[id]))
+		}
+		return nil
+	case "move":
+		id, err := parseID(params[1:])
+		if err != nil {
+			return err
+		}
+		_, _, err = locatePokemon(config, id)
+		if err != nil {
+			return err
+		}
+		n, err := parseBox(config, params[2:], true)
+		if err != nil {
+			return err
+		}
+		if len(config.Boxes[n]) >= boxCapacity {
+			return fmt.Errorf("box %d is full", n+1)
+		}
+		takePokemon(config, id)
+		config.Boxes[n] = append(config.Boxes[n], id)
+		fmt.Printf("%s was moved to box %d.\n", describeOwned(ctx, config, config.Owned[id]), n+1)
+		return nil
+	default:
+		return fmt.Errorf("unknown box option %q", params[0])
+	}
+}
+
+// parseBox reads a 1-based box number and returns its index. With grow set,
+// the box right after the last one is created on demand.
+func parseBox(config *Config, params []string, grow bool) (int, error) {
+	n, err := parsePositive(params)
+	if err != nil {
+		return 0, err
+	}
+	if grow && n == len(config.Boxes)+1 {
+		config.Boxes = append(config.Boxes, []int{})
+	}
+	if n > len(config.Boxes) {
+		return 0, fmt.Errorf("box %d does not exist, you have %d boxes", n, len(config.Boxes))
+	}
+	return n - 1, nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// newPartyConfig owns n Pokemon with IDs 1 to n, all stored as if they had
+// just been caught.
+func newPartyConfig(n int) *Config {
+	pokedexMap := make(map[string]PokemonEndpoint)
+	config := &Config{Owned: make(map[int]*OwnedPokemon), Pokedex: &pokedexMap}
+	for id := 1; id <= n; id++ {
+		config.Owned[id] = &OwnedPokemon{ID: id, Species: "pidgey", Level: 5}
+		storePokemon(config, id)
+	}
+	return config
+}
+
+func TestStorePokemon(t *testing.T) {
+	config := newPartyConfig(8)
+
+	if !reflect.DeepEqual(config.Party, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("expected the first 6 pokemon in the party, got %v", config.Party)
+	}
+	if !reflect.DeepEqual(config.Boxes, [][]int{{7, 8}}) {
+		t.Errorf("expected the rest in box 1, got %v", config.Boxes)
+	}
+}
+
+func TestPartyCommands(t *testing.T) {
+	config := newPartyConfig(8)
+	ctx := context.Background()
+
+	if err := commandParty(ctx, config, "add", "7"); err == nil {
+		t.Error("expected an error adding to a full party")
+	}
+	if err := commandParty(ctx, config, "swap", "2", "7"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(config.Party, []int{1, 7, 3, 4, 5, 6}) || !reflect.DeepEqual(config.Boxes[0], []int{2, 8}) {
+		t.Errorf("expected 2 and 7 to trade places, got party %v and boxes %v", config.Party, config.Boxes)
+	}
+	if err := commandParty(ctx, config, "remove", "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := commandParty(ctx, config, "add", "8"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(config.Party, []int{7, 3, 4, 5, 6, 8}) || !reflect.DeepEqual(config.Boxes[0], []int{2, 1}) {
+		t.Errorf("unexpected party %v and boxes %v", config.Party, config.Boxes)
+	}
+	if err := commandParty(ctx, config, "remove", "2"); err == nil {
+		t.Error("expected an error removing a pokemon that is not in the party")
+	}
+}
+
+func TestBoxMove(t *testing.T) {
+	config := newPartyConfig(7)
+	ctx := context.Background()
+
+	if err := commandBox(ctx, config, "move", "3", "2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(config.Boxes, [][]int{{7}, {3}}) {
+		t.Errorf("expected 3 in a new box 2, got %v", config.Boxes)
+	}
+	if err := commandBox(ctx, config, "move", "3", "4"); err == nil {
+		t.Error("expected an error moving to a box that does not exist")
+	}
+	if err := commandBox(ctx, config, "move", "99", "3"); err == nil {
+		t.Error("expected an error moving a pokemon you do not own")
+	}
+	if len(config.Boxes) != 2 {
+		t.Errorf("expected failed moves not to create boxes, got %v", config.Boxes)
+	}
+}
+
+func TestOrganizePokemon(t *testing.T) {
+	config := newPartyConfig(3)
+	config.Owned[4] = &OwnedPokemon{ID: 4}
+	config.Party = append(config.Party, 1, 99)
+
+	organizePokemon(config)
+	if !reflect.DeepEqual(config.Party, []int{1, 2, 3, 4}) {
+		t.Errorf("expected duplicates and unknown IDs dropped and 4 stored, got %v", config.Party)
+	}
+}
@@ -39,11 +39,13 @@ func (c *Cache) reapLoop() {
 	ticker := time.NewTicker(c.interval)
 	for {
 		limit := time.Now().Add(-c.interval)
+		c.mux.Lock()
 		for key, entry := range c.cacheMap {
-			if entry.createdAt.After(limit) {
+			if entry.createdAt.Before(limit) {
 				delete(c.cacheMap, key)
 			}
 		}
+		c.mux.Unlock()
 		<-ticker.C
 	}
 }
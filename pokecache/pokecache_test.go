@@ -59,3 +59,18 @@ func TestReapLoop(t *testing.T) {
 		return
 	}
 }
+
+func TestReapLoopKeepsFreshEntries(t *testing.T) {
+	const interval = 50 * time.Millisecond
+	cache := NewCache(interval)
+	time.Sleep(interval * 7 / 10)
+	cache.Add("https://example.com", []byte("testdata"))
+
+	// The first reap runs while the entry is still fresh.
+	time.Sleep(interval / 2)
+
+	_, ok := cache.Get("https://example.com")
+	if !ok {
+		t.Errorf("expected a fresh entry to survive a reap")
+	}
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const pokedexUsage = "use pokedex [--sort dex|name|caught|bst|<stat>] [--reverse] [--type <type>] " +
+	"[--generation <n>] [--tag <tag>] [--min <stat>=<n>] [--max <stat>=<n>] [--page <n>] [--size <n>]"
+
+// pokedexQuery is how the pokedex command sorts, filters and pages the
+// Pokemon caught so far.
+type pokedexQuery struct {
+	Sort       string
+	Reverse    bool
+	Type       string
+	Generation int
+	Tag        string
+	Min        map[string]int
+	Max        map[string]int
+	Page       int
+	Size       int
+}
+
+// pokedexEntry is a caught species with the Pokemon the trainer still has
+// of it.
+type pokedexEntry struct {
+	Data     PokemonEndpoint
+	Owned    []*OwnedPokemon
+	CaughtAt time.Time
+}
+
+// statValue returns a base stat of the entry, or the base stat total for
+// bst.
+func (e pokedexEntry) statValue(stat string) int {
+	if stat != "bst" {
+		return baseStat(e.Data, stat)
+	}
+	total := 0
+	for _, s := range e.Data.Stats {
+		total += s.BaseStat
+	}
+	return total
+}
+
+func isStatKey(key string) bool {
+	return key == "bst" || containsString(statNames, key)
+}
+
+func parsePokedexQuery(params []string) (pokedexQuery, error) {
+	query := pokedexQuery{Sort: "dex", Min: make(map[string]int), Max: make(map[string]int), Page: 1, Size: defaultPageSize}
+	for i := 0; i < len(params); i++ {
+		flag := params[i]
+		if flag == "--reverse" {
+			query.Reverse = true
+			continue
+		}
+		if i+1 >= len(params) {
+			return query, errors.New(pokedexUsage)
+		}
+		i++
+		value := strings.ToLower(params[i])
+		var err error
+		switch flag {
+		case "--sort":
+			if value != "dex" && value != "name" && value != "caught" && !isStatKey(value) {
+				return query, fmt.Errorf("can't sort by %q", value)
+			}
+			query.Sort = value
+		case "--type":
+			query.Type = value
+		case "--generation":
+			query.Generation, err = parseGeneration(value)
+		case "--tag":
+			query.Tag = value
+		case "--min", "--max":
+			bounds := query.Min
+			if flag == "--max" {
+				bounds = query.Max
+			}
+			err = parseStatBound(value, bounds)
+		case "--page":
+			query.Page, err = parsePositive([]string{value})
+		case "--size":
+			query.Size, err = parsePositive([]string{value})
+		default:
+			return query, errors.New(pokedexUsage)
+		}
+		if err != nil {
+			return query, err
+		}
+	}
+	return query, nil
+}
+
+// parseGeneration accepts a generation as a number or a name such as
+// generation-iii.
+func parseGeneration(value string) (int, error) {
+	if n := generationNumber(value); n > 0 {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%q is not a generation", value)
+	}
+	return n, nil
+}
+
+// parseStatBound parses a bound such as attack=80 into bounds.
+func parseStatBound(value string, bounds map[string]int) error {
+	stat, number, ok := strings.Cut(value, "=")
+	if !ok || !isStatKey(stat) {
+		return fmt.Errorf("use <stat>=<n> with a stat of bst, %s", strings.Join(statNames, ", "))
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return fmt.Errorf("%q is not a number", number)
+	}
+	bounds[stat] = n
+	return nil
+}
+
+// matches reports whether an entry passes every filter of the query but
+// the generation, which needs the species to be fetched.
+func (q pokedexQuery) matches(entry pokedexEntry) bool {
+	if q.Tag != "" && len(entry.Owned) == 0 {
+		return false
+	}
+	if q.Type != "" {
+		found := false
+		for _, t := range entry.Data.Types {
+			found = found || t.Type.Name == q.Type
+		}
+		if !found {
+			return false
+		}
+	}
+	for stat, n := range q.Min {
+		if entry.statValue(stat) < n {
+			return false
+		}
+	}
+	for stat, n := range q.Max {
+		if entry.statValue(stat) > n {
+			return false
+		}
+	}
+	return true
+}
+
+func (q pokedexQuery) less(a, b pokedexEntry) bool {
+	switch q.Sort {
+	case "name":
+		return a.Data.Name < b.Data.Name
+	case "caught":
+		// Species the trainer no longer has, say after evolving them, have
+		// no catch time and go last.
+		if a.CaughtAt.IsZero() != b.CaughtAt.IsZero() {
+			return !a.CaughtAt.IsZero()
+		}
+		return a.CaughtAt.Before(b.CaughtAt)
+	case "dex":
+		return a.Data.ID < b.Data.ID
+	default:
+		return a.statValue(q.Sort) < b.statValue(q.Sort)
+	}
+}
+
+// pokedexPokemon returns the PokeAPI data of a caught Pokemon. Entries
+// restored from a save only hold the name and ID, so the rest is fetched
+// the first time it is needed.
+func pokedexPokemon(ctx context.Context, config *Config, name string) (PokemonEndpoint, error) {
+	data, ok := (*config.Pokedex)[name]
+	if !ok {
+		return data, fmt.Errorf("no pokedex data for %s", name)
+	}
+	if len(data.Stats) > 0 {
+		return data, nil
+	}
+	data, err := fetchPokemon(ctx, config, name)
+	if err != nil {
+		return data, err
+	}
+	(*config.Pokedex)[name] = data
+	return data, nil
+}
+
+// pokedexEntries returns the caught species that pass the query, sorted.
+func pokedexEntries(ctx context.Context, config *Config, query pokedexQuery) ([]pokedexEntry, error) {
+	var entries []pokedexEntry
+	for name := range *config.Pokedex {
+		data, err := pokedexPokemon(ctx, config, name)
+		if err != nil {
+			return nil, err
+		}
+		entry := pokedexEntry{Data: data}
+		for _, owned := range ownedOfSpecies(config, data.Name) {
+			if query.Tag != "" && !owned.hasTag(query.Tag) {
+				continue
+			}
+			entry.Owned = append(entry.Owned, owned)
+			if entry.CaughtAt.IsZero() || owned.CaughtAt.Before(entry.CaughtAt) {
+				entry.CaughtAt = owned.CaughtAt
+			}
+		}
+		if !query.matches(entry) {
+			continue
+		}
+		if query.Generation > 0 {
+			species, err := fetchSpecies(ctx, config, data)
+			if err != nil {
+				return nil, err
+			}
+			if generationNumber(species.Generation.Name) != query.Generation {
+				continue
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if query.Reverse {
+			a, b = b, a
+		}
+		if query.less(a, b) {
+			return true
+		}
+		if query.less(b, a) {
+			return false
+		}
+		return a.Data.Name < b.Data.Name
+	})
+	return entries, nil
+}
+
+func commandPokedex(ctx context.Context, config *Config, params ...string) error {
+	query, err := parsePokedexQuery(params)
+	if err != nil {
+		return err
+	}
+	entries, err := pokedexEntries(ctx, config, query)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println("No matching pokemon in your Pokedex.")
+		return nil
+	}
+	page := Pagination{Offset: (query.Page - 1) * query.Size, Limit: query.Size, Count: len(entries)}
+	if page.Offset >= page.Count {
+		return fmt.Errorf("there are only %d pages", page.pageCount())
+	}
+
+	fmt.Println("Your Pokedex:")
+	for _, entry := range entries[page.Offset:min(page.Offset+page.Limit, page.Count)] {
+		types := make([]string, 0, len(entry.Data.Types))
+		for _, t := range entry.Data.Types {
+			types = append(types, t.Type.Name)
+		}
+		extra := ""
+		if isStatKey(query.Sort) && query.Sort != "bst" {
+			extra = fmt.Sprintf(", %s %d", query.Sort, entry.statValue(query.Sort))
+		}
+		fmt.Printf("- #%03d %s (%s) BST %d%s, %d owned\n", entry.Data.ID,
+			localName(ctx, config, "pokemon-species", entry.Data.Name),
+			strings.Join(localNames(ctx, config, "type", types), ", "),
+			entry.statValue("bst"), extra, len(entry.Owned))
+		for _, o := range entry.Owned {
+			if o.Nickname != "" || len(o.Tags) > 0 {
+				fmt.Printf("    #%d %s %s\n", o.ID, ownedName(ctx, config, o), formatTags(o.Tags))
+			}
+		}
+	}
+	if page.pageCount() > 1 {
+		fmt.Printf("Page %d of %d\n", page.pageNumber(), page.pageCount())
+	}
+	return nil
+}
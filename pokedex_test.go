@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newPokedexConfig sets up a Pokedex with bulbasaur, charmander and
+// pikachu, where only charmander and pikachu are still owned.
+func newPokedexConfig(t *testing.T) *Config {
+	t.Helper()
+	config := newTestConfig(t, fakeAPI{})
+	for _, raw := range []string{
+		`{"id": 1, "name": "bulbasaur", "types": [{"type": {"name": "grass"}}, {"type": {"name": "poison"}}],
+			"stats": [{"base_stat": 45, "stat": {"name": "hp"}}, {"base_stat": 49, "stat": {"name": "attack"}}]}`,
+		`{"id": 4, "name": "charmander", "types": [{"type": {"name": "fire"}}],
+			"stats": [{"base_stat": 39, "stat": {"name": "hp"}}, {"base_stat": 52, "stat": {"name": "attack"}}]}`,
+		`{"id": 25, "name": "pikachu", "types": [{"type": {"name": "electric"}}],
+			"stats": [{"base_stat": 35, "stat": {"name": "hp"}}, {"base_stat": 55, "stat": {"name": "attack"}}]}`,
+	} {
+		data := PokemonEndpoint{}
+		if err := json.Unmarshal([]byte(raw), &data); err != nil {
+			t.Fatal(err)
+		}
+		(*config.Pokedex)[data.Name] = data
+	}
+	now := time.Now()
+	config.Owned[1] = &OwnedPokemon{ID: 1, Species: "pikachu", CaughtAt: now.Add(-time.Hour), Tags: []string{"team"}}
+	config.Owned[2] = &OwnedPokemon{ID: 2, Species: "charmander", CaughtAt: now}
+	return config
+}
+
+func TestPokedexEntries(t *testing.T) {
+	config := newPokedexConfig(t)
+
+	cases := []struct {
+		params   []string
+		expected []string
+	}{
+		{nil, []string{"bulbasaur", "charmander", "pikachu"}},
+		{[]string{"--sort", "name", "--reverse"}, []string{"pikachu", "charmander", "bulbasaur"}},
+		{[]string{"--sort", "caught"}, []string{"pikachu", "charmander", "bulbasaur"}},
+		{[]string{"--sort", "attack"}, []string{"bulbasaur", "charmander", "pikachu"}},
+		{[]string{"--sort", "bst", "--reverse"}, []string{"bulbasaur", "charmander", "pikachu"}},
+		{[]string{"--type", "fire"}, []string{"charmander"}},
+		{[]string{"--tag", "team"}, []string{"pikachu"}},
+		{[]string{"--min", "attack=50", "--max", "hp=38"}, []string{"pikachu"}},
+	}
+	for _, c := range cases {
+		query, err := parsePokedexQuery(c.params)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", c.params, err)
+		}
+		entries, err := pokedexEntries(context.Background(), config, query)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", c.params, err)
+		}
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Data.Name)
+		}
+		if !reflect.DeepEqual(names, c.expected) {
+			t.Errorf("%v: expected %v, got %v", c.params, c.expected, names)
+		}
+	}
+}
+
+func TestParsePokedexQuery(t *testing.T) {
+	for _, params := range [][]string{
+		{"--sort", "weight"},
+		{"--min", "attack"},
+		{"--min", "luck=3"},
+		{"--generation", "zero"},
+		{"--page", "0"},
+		{"--type"},
+		{"--color", "red"},
+	} {
+		if _, err := parsePokedexQuery(params); err == nil {
+			t.Errorf("%v: expected an error", params)
+		}
+	}
+
+	query, err := parsePokedexQuery([]string{"--generation", "generation-iii", "--size", "5"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query.Generation != 3 || query.Size != 5 {
+		t.Errorf("expected generation 3 and size 5, got %d and %d", query.Generation, query.Size)
+	}
+}
+
+func TestPokedexPages(t *testing.T) {
+	config := newPokedexConfig(t)
+	ctx := context.Background()
+
+	if err := commandPokedex(ctx, config, "--size", "2", "--page", "2"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := commandPokedex(ctx, config, "--size", "2", "--page", "3"); err == nil {
+		t.Error("expected an error past the last page")
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// newRand returns the random source all game randomness draws from. A seed
+// of 0 picks one from the clock.
+func newRand(seed int64) (*rand.Rand, int64) {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(rand.NewSource(seed)), seed
+}
+
+func commandSeed(ctx context.Context, config *Config, params ...string) error {
+	if len(params) == 0 {
+		fmt.Println("Current seed:", config.Seed)
+		return nil
+	}
+	seed, err := strconv.ParseInt(params[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid seed", params[0])
+	}
+	if seed == 0 {
+		return errors.New("seed must not be 0")
+	}
+	config.Rand, config.Seed = newRand(seed)
+	fmt.Println("Seed set to", seed)
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func newSeededForestConfig(t *testing.T) *Config {
+	t.Helper()
+	pikachu := map[string]any{"name": "pikachu", "stats": []map[string]any{{"base_stat": 35, "stat": NamedAPIResource{Name: "hp"}}}}
+	caterpie := map[string]any{"name": "caterpie", "stats": []map[string]any{{"base_stat": 45, "stat": NamedAPIResource{Name: "hp"}}}}
+	config := newTestConfig(t, fakeAPI{
+		"/location-area/viridian-forest-area/": json.RawMessage(forestAreaJSON),
+		"/pokemon/pikachu/":                    pikachu,
+		"/pokemon/caterpie/":                   caterpie,
+	})
+	config.CurrentArea = "viridian-forest-area"
+	return config
+}
+
+// encounterRun rolls n encounters after seeding config and returns them.
+func encounterRun(t *testing.T, config *Config, seed string, n int) []WildPokemon {
+	t.Helper()
+	ctx := context.Background()
+	if err := commandSeed(ctx, config, seed); err != nil {
+		t.Fatalf("unexpected error seeding: %v", err)
+	}
+	var wilds []WildPokemon
+	for i := 0; i < n; i++ {
+		if err := commandEncounter(ctx, config); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		wilds = append(wilds, *config.Wild)
+	}
+	return wilds
+}
+
+func TestSeedReproducesEncounters(t *testing.T) {
+	first := encounterRun(t, newSeededForestConfig(t), "42", 20)
+	second := encounterRun(t, newSeededForestConfig(t), "42", 20)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected the same encounters for the same seed\n%v\n%v", first, second)
+	}
+
+	config := newSeededForestConfig(t)
+	encounterRun(t, config, "7", 5)
+	again := encounterRun(t, config, "42", 20)
+	if !reflect.DeepEqual(first, again) {
+		t.Errorf("expected reseeding to restart the random source\n%v\n%v", first, again)
+	}
+}
+
+func TestSeedReproducesShakes(t *testing.T) {
+	roll := func() []int {
+		rng, _ := newRand(1234)
+		var shakes []int
+		for i := 0; i < 50; i++ {
+			shakes = append(shakes, rollShakes(rng, 45))
+		}
+		return shakes
+	}
+	if first, second := roll(), roll(); !reflect.DeepEqual(first, second) {
+		t.Errorf("expected the same shakes for the same seed\n%v\n%v", first, second)
+	}
+}
+
+func TestSeedCommandRejectsInvalidSeeds(t *testing.T) {
+	config := &Config{}
+	for _, seed := range []string{"0", "abc"} {
+		if err := commandSeed(context.Background(), config, seed); err == nil {
+			t.Errorf("expected an error for seed %q", seed)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// SaveData is the part of Config that is kept between sessions.
+type SaveData struct {
+	CurrentArea  string                       `json:"current_area,omitempty"`
+	Explored     map[string]bool              `json:"explored,omitempty"`
+	Seen         map[string]bool              `json:"seen,omitempty"`
+	Version      string                       `json:"version,omitempty"`
+	Language     string                       `json:"language,omitempty"`
+	Names        map[string]map[string]string `json:"names,omitempty"`
+	Inventory    map[string]int               `json:"inventory"`
+	Money        int                          `json:"money"`
+	Pokedex      map[string]savedPokemon      `json:"pokedex"`
+	Owned        map[int]*OwnedPokemon        `json:"owned"`
+	Party        []int                        `json:"party"`
+	Boxes        [][]int                      `json:"boxes"`
+	PendingMoves []pendingMove                `json:"pending_moves,omitempty"`
+}
+
+// savedPokemon is what the save keeps of a Pokedex entry. The rest of the
+// PokeAPI data is fetched again when needed. Saves that stored the whole
+// entry decode into it too.
+type savedPokemon struct {
+	ID      int              `json:"id"`
+	Name    string           `json:"name"`
+	Species NamedAPIResource `json:"species"`
+}
+
+// defaultSavePath returns the save file location in the user's config
+// directory, falling back to the working directory.
+func defaultSavePath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "pokedex_save.json"
+	}
+	return filepath.Join(dir, "pokedexcli", "save.json")
+}
+
+// loadGame restores the saved state into config. A missing save file is
+// not an error; it simply means a new game.
+func loadGame(config *Config) error {
+	raw, err := os.ReadFile(config.SavePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Fields missing from older saves keep their new game values.
+	data := SaveData{Money: config.Money}
+	err = json.Unmarshal(raw, &data)
+	if err != nil {
+		return fmt.Errorf("reading save %s: %w", config.SavePath, err)
+	}
+	config.CurrentArea = data.CurrentArea
+	config.Version = data.Version
+	config.Language = data.Language
+	if data.Names != nil {
+		config.NameIndex = data.Names
+	}
+	if data.Inventory != nil {
+		config.Inventory = data.Inventory
+	}
+	config.Money = data.Money
+	if data.Explored != nil {
+		config.Explored = data.Explored
+	}
+	if data.Seen != nil {
+		config.Seen = data.Seen
+	}
+	if data.Pokedex != nil {
+		pokedex := make(map[string]PokemonEndpoint, len(data.Pokedex))
+		for name, saved := range data.Pokedex {
+			entry := PokemonEndpoint{ID: saved.ID, Name: saved.Name}
+			entry.Species.Name = saved.Species.Name
+			entry.Species.URL = saved.Species.URL
+			pokedex[name] = entry
+		}
+		*config.Pokedex = pokedex
+	}
+	if data.Owned != nil {
+		config.Owned = data.Owned
+	}
+	config.Party = data.Party
+	config.Boxes = data.Boxes
+	config.PendingMoves = data.PendingMoves
+	organizePokemon(config)
+	return nil
+}
+
+func saveGame(config *Config) error {
+	pokedex := make(map[string]savedPokemon, len(*config.Pokedex))
+	for name, entry := range *config.Pokedex {
+		pokedex[name] = savedPokemon{
+			ID:      entry.ID,
+			Name:    entry.Name,
+			Species: NamedAPIResource{Name: entry.Species.Name, URL: entry.Species.URL},
+		}
+	}
+	data := SaveData{
+		CurrentArea:  config.CurrentArea,
+		Explored:     config.Explored,
+		Seen:         config.Seen,
+		Version:      config.Version,
+		Language:     config.Language,
+		Names:        config.NameIndex,
+		Inventory:    config.Inventory,
+		Money:        config.Money,
+		Pokedex:      pokedex,
+		Owned:        config.Owned,
+		Party:        config.Party,
+		Boxes:        config.Boxes,
+		PendingMoves: config.PendingMoves,
+	}
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(config.SavePath), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(config.SavePath, raw, 0o644)
+}
+
+func commandSave(ctx context.Context, config *Config) error {
+	err := saveGame(config)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Game saved to", config.SavePath)
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "save.json")
+	pokedexMap := map[string]PokemonEndpoint{"pidgey": {ID: 16, Name: "pidgey"}}
+	config := &Config{SavePath: path, CurrentArea: "viridian-forest-area", Pokedex: &pokedexMap}
+	if err := saveGame(config); err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+
+	emptyPokedex := make(map[string]PokemonEndpoint)
+	loaded := &Config{SavePath: path, Pokedex: &emptyPokedex}
+	if err := loadGame(loaded); err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if loaded.CurrentArea != "viridian-forest-area" {
+		t.Errorf("expected current area to be restored, got %q", loaded.CurrentArea)
+	}
+	if (*loaded.Pokedex)["pidgey"].ID != 16 {
+		t.Errorf("expected pidgey to be restored, got %v", *loaded.Pokedex)
+	}
+}
+
+func TestSaveKeepsPokedexSmall(t *testing.T) {
+	config := newTestConfig(t, fakeAPI{
+		"/pokemon/pikachu/": map[string]any{"id": 25, "name": "pikachu", "stats": baseStats(35, 55, 40, 50, 50, 90)},
+	})
+	config.SavePath = filepath.Join(t.TempDir(), "save.json")
+	pikachu := PokemonEndpoint{}
+	err := json.Unmarshal([]byte(`{"id": 25, "name": "pikachu", "species": {"name": "pikachu"},
+		"stats": [{"base_stat": 35, "stat": {"name": "hp"}}], "sprites": {"front_default": "pikachu.png"}}`), &pikachu)
+	if err != nil {
+		t.Fatal(err)
+	}
+	(*config.Pokedex)["pikachu"] = pikachu
+	if err := saveGame(config); err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+	raw, err := os.ReadFile(config.SavePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "sprites") || strings.Contains(string(raw), "stats") {
+		t.Errorf("expected only the name and ID of pokedex entries to be saved, got %s", raw)
+	}
+
+	*config.Pokedex = make(map[string]PokemonEndpoint)
+	if err := loadGame(config); err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if (*config.Pokedex)["pikachu"].Species.Name != "pikachu" {
+		t.Errorf("expected the species to be restored, got %v", (*config.Pokedex)["pikachu"])
+	}
+	data, err := pokedexPokemon(context.Background(), config, "pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if baseStat(data, "speed") != 90 {
+		t.Errorf("expected the pokedex entry to be fetched again, got %v", data.Stats)
+	}
+}
+
+func TestLoadMissingSave(t *testing.T) {
+	pokedexMap := make(map[string]PokemonEndpoint)
+	config := &Config{SavePath: filepath.Join(t.TempDir(), "missing.json"), Pokedex: &pokedexMap}
+	if err := loadGame(config); err != nil {
+		t.Errorf("expected a missing save to start a new game, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type VersionEndpoint struct {
+	ID           int              `json:"id"`
+	Name         string           `json:"name"`
+	Names        []Name           `json:"names"`
+	VersionGroup NamedAPIResource `json:"version_group"`
+}
+
+type VersionGroupEndpoint struct {
+	ID         int                `json:"id"`
+	Name       string             `json:"name"`
+	Generation NamedAPIResource   `json:"generation"`
+	Regions    []NamedAPIResource `json:"regions"`
+	Versions   []NamedAPIResource `json:"versions"`
+	Pokedexes  []NamedAPIResource `json:"pokedexes"`
+}
+
+// allVersions is the version setting that turns version filtering off.
+const allVersions = "all"
+
+func commandSet(ctx context.Context, config *Config, params ...string) error {
+	if len(params) < 2 {
+		return errors.New("use set <setting> <value>, settings: version, language")
+	}
+	setting, value := params[0], strings.ToLower(params[1])
+	switch setting {
+	case "version":
+		err := setVersion(ctx, config, value)
+		if err != nil {
+			return err
+		}
+		if config.Version == "" {
+			fmt.Println("Showing encounters from all game versions.")
+		} else {
+			fmt.Println("Game version set to", config.Version)
+		}
+		return nil
+	case "language":
+		err := setLanguage(ctx, config, value)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Language set to", config.Language)
+		return nil
+	default:
+		return fmt.Errorf("unknown setting %q", setting)
+	}
+}
+
+// setVersion selects the game version whose encounters are shown, after
+// checking that the PokeAPI knows it.
+func setVersion(ctx context.Context, config *Config, version string) error {
+	if version == allVersions {
+		config.Version = ""
+		return nil
+	}
+	data := VersionEndpoint{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/version/%s/", config.BaseURL, version), &data)
+	if err != nil {
+		return fmt.Errorf("unknown game version %s: %w", version, err)
+	}
+	config.Version = data.Name
+	return nil
+}
+
+// inVersion reports whether a detail of the given version passes the
+// version setting.
+func inVersion(config *Config, version string) bool {
+	return config.Version == "" || config.Version == version
+}
+
+// areaInVersion reports whether the area has any encounters in the selected
+// game version.
+func areaInVersion(config *Config, data LocationAreaEndpoint) bool {
+	if config.Version == "" {
+		return true
+	}
+	for _, rate := range data.EncounterMethodRates {
+		for _, version := range rate.VersionDetails {
+			if version.Version.Name == config.Version {
+				return true
+			}
+		}
+	}
+	for _, encounter := range data.PokemonEncounters {
+		for _, version := range encounter.VersionDetails {
+			if version.Version.Name == config.Version {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// currentVersionGroup returns the version group of the selected game
+// version, or "" when no version is selected.
+func currentVersionGroup(ctx context.Context, config *Config) (string, error) {
+	if config.Version == "" {
+		return "", nil
+	}
+	data := VersionEndpoint{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/version/%s/", config.BaseURL, config.Version), &data)
+	if err != nil {
+		return "", err
+	}
+	return data.VersionGroup.Name, nil
+}
+
+// currentGeneration returns the generation number of the selected game
+// version, or 0 when no version is selected.
+func currentGeneration(ctx context.Context, config *Config) (int, error) {
+	versionGroup, err := currentVersionGroup(ctx, config)
+	if err != nil || versionGroup == "" {
+		return 0, err
+	}
+	data := VersionGroupEndpoint{}
+	err = fetchJSON(ctx, config, fmt.Sprintf("%s/version-group/%s/", config.BaseURL, versionGroup), &data)
+	if err != nil {
+		return 0, err
+	}
+	return generationNumber(data.Generation.Name), nil
+}
+
+// generationNumber turns a generation name such as generation-iv into its
+// number, or 0 if it is not one.
+func generationNumber(generation string) int {
+	numerals := map[byte]int{'i': 1, 'v': 5, 'x': 10}
+	roman, ok := strings.CutPrefix(generation, "generation-")
+	if !ok {
+		return 0
+	}
+	number := 0
+	for i := 0; i < len(roman); i++ {
+		value := numerals[roman[i]]
+		if value == 0 {
+			return 0
+		}
+		if i+1 < len(roman) && numerals[roman[i+1]] > value {
+			number -= value
+		} else {
+			number += value
+		}
+	}
+	return number
+}
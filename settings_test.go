@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAreaInVersion(t *testing.T) {
+	data := decodeForestArea(t)
+
+	cases := []struct {
+		version  string
+		expected bool
+	}{
+		{"", true},
+		{"red", true},
+		{"yellow", true},
+		{"platinum", false},
+	}
+	for _, c := range cases {
+		config := &Config{Version: c.version}
+		if got := areaInVersion(config, data); got != c.expected {
+			t.Errorf("version %q: expected %v, got %v", c.version, c.expected, got)
+		}
+	}
+}
+
+func TestSetVersion(t *testing.T) {
+	config := newTestConfig(t, fakeAPI{
+		"/version/platinum/": VersionEndpoint{Name: "platinum"},
+	})
+	ctx := context.Background()
+
+	if err := commandSet(ctx, config, "version", "Platinum"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Version != "platinum" {
+		t.Errorf("expected version platinum, got %q", config.Version)
+	}
+	if err := commandSet(ctx, config, "version", "stadium"); err == nil {
+		t.Error("expected an error for an unknown version")
+	}
+	if config.Version != "platinum" {
+		t.Errorf("expected an unknown version to keep platinum, got %q", config.Version)
+	}
+	if err := commandSet(ctx, config, "version", "all"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Version != "" {
+		t.Errorf("expected version filtering to be off, got %q", config.Version)
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	starterMoney = 3000
+	// maxItemQuantity is how many of one item the bag holds, as in the
+	// games.
+	maxItemQuantity = 999
+)
+
+// martStock lists the items the Poke Mart sells.
+var martStock = []string{
+	"poke-ball",
+	"great-ball",
+	"ultra-ball",
+	"potion",
+	"super-potion",
+	"hyper-potion",
+	"antidote",
+	"paralyze-heal",
+	"awakening",
+	"burn-heal",
+	"ice-heal",
+	"full-heal",
+	"revive",
+	"fire-stone",
+	"water-stone",
+	"thunder-stone",
+	"leaf-stone",
+	"moon-stone",
+}
+
+func inMart(item string) bool {
+	for _, stocked := range martStock {
+		if stocked == item {
+			return true
+		}
+	}
+	return false
+}
+
+// parseQuantity reads the optional quantity argument, defaulting to 1.
+func parseQuantity(params []string) (int, error) {
+	if len(params) == 0 {
+		return 1, nil
+	}
+	return parsePositive(params)
+}
+
+func commandShop(ctx context.Context, config *Config) error {
+	fmt.Printf("Welcome to the Poke Mart! You have $%d.\n", config.Money)
+	for _, item := range martStock {
+		data, err := fetchItem(ctx, config, item)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("- %s $%d: %s\n", pickName(config, "item", item, data.Names), data.Cost, data.shortEffect())
+	}
+	return nil
+}
+
+func commandBuy(ctx context.Context, config *Config, params ...string) error {
+	if len(params) == 0 {
+		return errors.New("missing item name")
+	}
+	item := resolveNameAmong(ctx, config, "item", params[0], martStock)
+	if !inMart(item) {
+		return fmt.Errorf("the Poke Mart does not sell %s", item)
+	}
+	quantity, err := parseQuantity(params[1:])
+	if err != nil {
+		return err
+	}
+	data, err := fetchItem(ctx, config, item)
+	if err != nil {
+		return err
+	}
+
+	if quantity > maxItemQuantity-config.Inventory[item] {
+		return fmt.Errorf("your bag can hold at most %d %s", maxItemQuantity, item)
+	}
+	// Checking by division first keeps huge quantities from overflowing
+	// the price.
+	if data.Cost > 0 && quantity > config.Money/data.Cost {
+		return fmt.Errorf("%d %s cost $%d each but you only have $%d", quantity, item, data.Cost, config.Money)
+	}
+	price := data.Cost * quantity
+	config.Money -= price
+	config.Inventory[item] += quantity
+	fmt.Printf("You bought %d %s for $%d. You have $%d left.\n", quantity, pickName(config, "item", item, data.Names),
+		price, config.Money)
+	return nil
+}
+
+func commandSell(ctx context.Context, config *Config, params ...string) error {
+	if len(params) == 0 {
+		return errors.New("missing item name")
+	}
+	item := resolveNameAmong(ctx, config, "item", params[0], bagItems(config))
+	quantity, err := parseQuantity(params[1:])
+	if err != nil {
+		return err
+	}
+	if config.Inventory[item] < quantity {
+		return fmt.Errorf("you only have %d %s", config.Inventory[item], item)
+	}
+	data, err := fetchItem(ctx, config, item)
+	if err != nil {
+		return err
+	}
+	if data.Cost == 0 {
+		return fmt.Errorf("%s can't be sold", item)
+	}
+
+	// Items sell for half their price, as in the games.
+	price := data.Cost / 2 * quantity
+	config.Inventory[item] -= quantity
+	if config.Inventory[item] == 0 {
+		delete(config.Inventory, item)
+	}
+	config.Money += price
+	fmt.Printf("You sold %d %s for $%d. You have $%d.\n", quantity, pickName(config, "item", item, data.Names),
+		price, config.Money)
+	return nil
+}
+
+// reward gives the trainer money and says so.
+func reward(config *Config, amount int, reason string) {
+	config.Money += amount
+	fmt.Printf("You earned $%d for %s.\n", amount, reason)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func newShopConfig(t *testing.T) *Config {
+	t.Helper()
+	config := newTestConfig(t, fakeAPI{
+		"/item/poke-ball/":   ItemEndpoint{Name: "poke-ball", Cost: 200},
+		"/item/master-ball/": ItemEndpoint{Name: "master-ball", Cost: 0},
+	})
+	config.Money = 1000
+	return config
+}
+
+func TestBuy(t *testing.T) {
+	config := newShopConfig(t)
+	ctx := context.Background()
+
+	if err := commandBuy(ctx, config, "poke-ball", "3"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Money != 400 || config.Inventory["poke-ball"] != 13 {
+		t.Errorf("expected $400 and 13 poke-balls, got $%d and %d", config.Money, config.Inventory["poke-ball"])
+	}
+
+	if err := commandBuy(ctx, config, "poke-ball", "3"); err == nil {
+		t.Error("expected an error buying more than you can afford")
+	}
+	if err := commandBuy(ctx, config, "master-ball"); err == nil {
+		t.Error("expected an error buying an item the mart does not sell")
+	}
+	if err := commandBuy(ctx, config, "poke-ball", "9223372036854775807"); err == nil {
+		t.Error("expected an error buying a huge quantity")
+	}
+	if config.Money != 400 || config.Inventory["poke-ball"] != 13 {
+		t.Errorf("expected failed purchases to change nothing, got $%d and %d", config.Money, config.Inventory["poke-ball"])
+	}
+
+	config.Money = 1000000
+	if err := commandBuy(ctx, config, "poke-ball", "990"); err == nil {
+		t.Error("expected an error buying more than the bag holds")
+	}
+	if config.Inventory["poke-ball"] != 13 {
+		t.Errorf("expected 13 poke-balls, got %d", config.Inventory["poke-ball"])
+	}
+}
+
+func TestSell(t *testing.T) {
+	config := newShopConfig(t)
+	config.Inventory = map[string]int{"poke-ball": 2, "master-ball": 1}
+	ctx := context.Background()
+
+	if err := commandSell(ctx, config, "poke-ball", "2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Money != 1200 {
+		t.Errorf("expected $1200, got $%d", config.Money)
+	}
+	if _, ok := config.Inventory["poke-ball"]; ok {
+		t.Error("expected sold out poke-balls to leave the bag")
+	}
+
+	if err := commandSell(ctx, config, "master-ball"); err == nil {
+		t.Error("expected an error selling an item without a price")
+	}
+	if err := commandSell(ctx, config, "poke-ball"); err == nil {
+		t.Error("expected an error selling an item you do not have")
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+type PokemonSpeciesEndpoint struct {
+	ID             int              `json:"id"`
+	Name           string           `json:"name"`
+	Names          []Name           `json:"names"`
+	CaptureRate    int              `json:"capture_rate"`
+	GenderRate     int              `json:"gender_rate"`
+	GrowthRate     NamedAPIResource `json:"growth_rate"`
+	BaseHappiness  int              `json:"base_happiness"`
+	EvolutionChain APIResource      `json:"evolution_chain"`
+	Genera         []struct {
+		Genus    string           `json:"genus"`
+		Language NamedAPIResource `json:"language"`
+	} `json:"genera"`
+	FlavorTextEntries []struct {
+		FlavorText string           `json:"flavor_text"`
+		Language   NamedAPIResource `json:"language"`
+		Version    NamedAPIResource `json:"version"`
+	} `json:"flavor_text_entries"`
+	Habitat     *NamedAPIResource  `json:"habitat"`
+	Color       NamedAPIResource   `json:"color"`
+	Shape       *NamedAPIResource  `json:"shape"`
+	Generation  NamedAPIResource   `json:"generation"`
+	IsLegendary bool               `json:"is_legendary"`
+	IsMythical  bool               `json:"is_mythical"`
+	EggGroups   []NamedAPIResource `json:"egg_groups"`
+}
+
+// fetchSpecies returns the species data of a Pokemon.
+func fetchSpecies(ctx context.Context, config *Config, pokemon PokemonEndpoint) (PokemonSpeciesEndpoint, error) {
+	data := PokemonSpeciesEndpoint{}
+	err := fetchJSON(ctx, config, pokemon.Species.URL, &data)
+	return data, err
+}
+
+func fetchPokemon(ctx context.Context, config *Config, pokemon string) (PokemonEndpoint, error) {
+	data := PokemonEndpoint{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/pokemon/%s/", config.BaseURL, pokemon), &data)
+	return data, err
+}
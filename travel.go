@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+func commandTravel(ctx context.Context, config *Config, area string) error {
+	if config.Battle != nil {
+		return errInBattle
+	}
+	areaData, err := fetchArea(ctx, config, area)
+	if err != nil {
+		return err
+	}
+	if !areaInVersion(config, areaData) {
+		return fmt.Errorf("%s is not in pokemon %s", area, config.Version)
+	}
+	locationData := LocationEndpoint{}
+	err = fetchJSON(ctx, config, areaData.Location.URL, &locationData)
+	if err != nil {
+		return err
+	}
+
+	config.CurrentArea = areaData.Name
+	config.Wild = nil
+	fmt.Printf("You travel to %s in %s.\n", pickName(config, "location-area", areaData.Name, areaData.Names),
+		pickName(config, "location", locationData.Name, locationData.Names))
+	if len(locationData.Areas) > 1 {
+		fmt.Println("Nearby areas:")
+		for _, nearby := range locationData.Areas {
+			if nearby.Name != areaData.Name {
+				fmt.Println("-", localName(ctx, config, "location-area", nearby.Name))
+			}
+		}
+	}
+	return nil
+}
+
+// resolveArea returns the area a command acts on: the current area when
+// none is given, and an error when the trainer is somewhere else. Free mode
+// accepts any area.
+func resolveArea(config *Config, params []string) (string, error) {
+	if config.FreeMode && len(params) > 0 {
+		return resolveName(config, "location-area", params[0]), nil
+	}
+	if config.CurrentArea == "" {
+		return "", errors.New("you are not in any area yet, use travel <area> first")
+	}
+	if len(params) > 0 && resolveName(config, "location-area", params[0]) != config.CurrentArea {
+		return "", fmt.Errorf("you are in %s, travel to %s first", config.CurrentArea, params[0])
+	}
+	return config.CurrentArea, nil
+}
+
+func fetchArea(ctx context.Context, config *Config, area string) (LocationAreaEndpoint, error) {
+	data := LocationAreaEndpoint{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/location-area/%s/", config.BaseURL, area), &data)
+	return data, err
+}
+
+// areaHasPokemon reports whether pokemon appears in the area in the
+// selected game version.
+func areaHasPokemon(config *Config, data LocationAreaEndpoint, pokemon string) bool {
+	for _, encounter := range data.PokemonEncounters {
+		if encounter.Pokemon.Name != pokemon {
+			continue
+		}
+		for _, version := range encounter.VersionDetails {
+			if inVersion(config, version.Version.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkEncountered reports an error unless pokemon is the current wild
+// encounter or was found when exploring the current area.
+func checkEncountered(ctx context.Context, config *Config, pokemon string) error {
+	if wildInArea(config, pokemon) {
+		return nil
+	}
+	area, err := resolveArea(config, nil)
+	if err != nil {
+		return err
+	}
+	if !config.Explored[area] {
+		return fmt.Errorf("you have not explored %s yet, use explore first", area)
+	}
+	areaData, err := fetchArea(ctx, config, area)
+	if err != nil {
+		return err
+	}
+	if !areaHasPokemon(config, areaData, pokemon) {
+		return fmt.Errorf("there is no %s in %s", pokemon, area)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func newForestConfig(t *testing.T) *Config {
+	t.Helper()
+	config := newTestConfig(t, fakeAPI{
+		"/location-area/viridian-forest-area/": map[string]any{
+			"name":     "viridian-forest-area",
+			"location": NamedAPIResource{Name: "viridian-forest", URL: "BASE/location/viridian-forest/"},
+			"pokemon_encounters": []map[string]any{
+				{"pokemon": NamedAPIResource{Name: "caterpie"}, "version_details": []map[string]any{
+					{"version": NamedAPIResource{Name: "red"}, "max_chance": 50},
+				}},
+				{"pokemon": NamedAPIResource{Name: "pikachu"}, "version_details": []map[string]any{
+					{"version": NamedAPIResource{Name: "yellow"}, "max_chance": 5},
+				}},
+			},
+		},
+		"/location/viridian-forest/": LocationEndpoint{
+			Name:  "viridian-forest",
+			Areas: []NamedAPIResource{{Name: "viridian-forest-area"}},
+		},
+	})
+	return config
+}
+
+func TestTravelSetsCurrentArea(t *testing.T) {
+	config := newForestConfig(t)
+	ctx := context.Background()
+
+	if err := commandTravel(ctx, config, "viridian-forest-area"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.CurrentArea != "viridian-forest-area" {
+		t.Errorf("expected current area viridian-forest-area, got %q", config.CurrentArea)
+	}
+	if err := commandTravel(ctx, config, "cerulean-cave-1f"); err == nil {
+		t.Error("expected an error travelling to an unknown area")
+	}
+	if config.CurrentArea != "viridian-forest-area" {
+		t.Errorf("expected a failed travel to keep the current area, got %q", config.CurrentArea)
+	}
+}
+
+func TestCheckEncountered(t *testing.T) {
+	config := newForestConfig(t)
+	ctx := context.Background()
+
+	if err := checkEncountered(ctx, config, "pikachu"); err == nil {
+		t.Error("expected an error before travelling anywhere")
+	}
+
+	config.CurrentArea = "viridian-forest-area"
+	if err := checkEncountered(ctx, config, "pikachu"); err == nil {
+		t.Error("expected an error before exploring the area")
+	}
+
+	if err := exploreArea(ctx, config, "viridian-forest-area"); err != nil {
+		t.Fatalf("unexpected error exploring: %v", err)
+	}
+	if err := checkEncountered(ctx, config, "pikachu"); err != nil {
+		t.Errorf("expected pikachu to be catchable, got %v", err)
+	}
+	if err := checkEncountered(ctx, config, "mewtwo"); err == nil {
+		t.Error("expected mewtwo not to be catchable in viridian forest")
+	}
+
+	config.Version = "red"
+	if err := checkEncountered(ctx, config, "caterpie"); err != nil {
+		t.Errorf("expected caterpie to be catchable in red, got %v", err)
+	}
+	if err := checkEncountered(ctx, config, "pikachu"); err == nil {
+		t.Error("expected pikachu not to be catchable in red, where it does not appear")
+	}
+}
+
+func TestResolveArea(t *testing.T) {
+	config := &Config{CurrentArea: "viridian-forest-area"}
+
+	if area, err := resolveArea(config, nil); err != nil || area != "viridian-forest-area" {
+		t.Errorf("expected the current area, got %q, %v", area, err)
+	}
+	if _, err := resolveArea(config, []string{"cerulean-cave-1f"}); err == nil {
+		t.Error("expected an error for an area the trainer is not in")
+	}
+
+	config.FreeMode = true
+	if area, err := resolveArea(config, []string{"cerulean-cave-1f"}); err != nil || area != "cerulean-cave-1f" {
+		t.Errorf("expected free mode to accept any area, got %q, %v", area, err)
+	}
+}
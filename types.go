@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type TypeEndpoint struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	Names           []Name `json:"names"`
+	DamageRelations struct {
+		DoubleDamageTo   []NamedAPIResource `json:"double_damage_to"`
+		HalfDamageTo     []NamedAPIResource `json:"half_damage_to"`
+		NoDamageTo       []NamedAPIResource `json:"no_damage_to"`
+		DoubleDamageFrom []NamedAPIResource `json:"double_damage_from"`
+		HalfDamageFrom   []NamedAPIResource `json:"half_damage_from"`
+		NoDamageFrom     []NamedAPIResource `json:"no_damage_from"`
+	} `json:"damage_relations"`
+}
+
+// typeChart holds how effective each attacking type is against each
+// defending type. Pairs that are missing are neutral.
+type typeChart map[string]map[string]float64
+
+// effectiveness returns the combined multiplier of an attacking type
+// against all the types of a defender.
+func (chart typeChart) effectiveness(attackType string, defenderTypes []string) float64 {
+	multiplier := 1.0
+	for _, defender := range defenderTypes {
+		if value, ok := chart[attackType][defender]; ok {
+			multiplier *= value
+		}
+	}
+	return multiplier
+}
+
+// attackTypes returns the attacking types of the chart in name order.
+func (chart typeChart) attackTypes() []string {
+	types := make([]string, 0, len(chart))
+	for t := range chart {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// loadTypeChart builds the type chart from every type endpoint the first
+// time it is needed and keeps it in config afterwards.
+func loadTypeChart(ctx context.Context, config *Config) (typeChart, error) {
+	if config.TypeChart != nil {
+		return config.TypeChart, nil
+	}
+	list := NamedAPIResourceList{}
+	err := fetchJSON(ctx, config, fmt.Sprintf("%s/type/?limit=100", config.BaseURL), &list)
+	if err != nil {
+		return nil, err
+	}
+
+	chart := make(typeChart)
+	for _, t := range list.Results {
+		data := TypeEndpoint{}
+		err := fetchJSON(ctx, config, fmt.Sprintf("%s/type/%s/", config.BaseURL, t.Name), &data)
+		if err != nil {
+			return nil, err
+		}
+		row := make(map[string]float64)
+		for _, defender := range data.DamageRelations.DoubleDamageTo {
+			row[defender.Name] = 2
+		}
+		for _, defender := range data.DamageRelations.HalfDamageTo {
+			row[defender.Name] = 0.5
+		}
+		for _, defender := range data.DamageRelations.NoDamageTo {
+			row[defender.Name] = 0
+		}
+		chart[data.Name] = row
+	}
+	config.TypeChart = chart
+	return chart, nil
+}
+
+// typeMultiplier returns the combined effectiveness of an attack type
+// against all the types of the defender.
+func typeMultiplier(ctx context.Context, config *Config, attackType string, defenderTypes []string) (float64, error) {
+	chart, err := loadTypeChart(ctx, config)
+	if err != nil {
+		return 0, err
+	}
+	return chart.effectiveness(attackType, defenderTypes), nil
+}
+
+// typesOf returns the types of a Pokemon in the selected game version,
+// which differ from its current ones when it gained a type later on, such
+// as Clefairy becoming fairy type in generation VI.
+func typesOf(ctx context.Context, config *Config, data PokemonEndpoint) ([]string, error) {
+	generation, err := currentGeneration(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return typesInGeneration(data, generation), nil
+}
+
+// typesInGeneration returns the types of a Pokemon in generation, where 0
+// means the current generation. Past types apply up to and including their
+// generation, so the earliest one at or after generation wins.
+func typesInGeneration(data PokemonEndpoint, generation int) []string {
+	slots := data.Types
+	if generation > 0 {
+		best := 0
+		for _, past := range data.PastTypes {
+			pastGeneration := generationNumber(past.Generation.Name)
+			if pastGeneration >= generation && (best == 0 || pastGeneration < best) {
+				best = pastGeneration
+				slots = past.Types
+			}
+		}
+	}
+
+	types := make([]string, 0, len(slots))
+	for _, t := range slots {
+		types = append(types, t.Type.Name)
+	}
+	return types
+}
+
+// resolveSpecies turns the ID or nickname of an owned Pokemon into its
+// species, and a localized name into its slug.
+func resolveSpecies(ctx context.Context, config *Config, pokemon string) string {
+	owned, err := findOwned(config, pokemon)
+	if err != nil {
+		return resolveNameAmong(ctx, config, "pokemon-species", pokemon, knownSpecies(config))
+	}
+	return owned.Species
+}
+
+func commandWeakness(ctx context.Context, config *Config, params ...string) error {
+	if len(params) == 0 {
+		return errors.New("missing pokemon name")
+	}
+	data, err := fetchPokemon(ctx, config, resolveSpecies(ctx, config, params[0]))
+	if err != nil {
+		return err
+	}
+	types, err := typesOf(ctx, config, data)
+	if err != nil {
+		return err
+	}
+	chart, err := loadTypeChart(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	byMultiplier := make(map[float64][]string)
+	for _, attackType := range chart.attackTypes() {
+		multiplier := chart.effectiveness(attackType, types)
+		if multiplier != 1 {
+			byMultiplier[multiplier] = append(byMultiplier[multiplier], attackType)
+		}
+	}
+
+	fmt.Printf("%s (%s) takes:\n", localName(ctx, config, "pokemon-species", data.Name),
+		strings.Join(localNames(ctx, config, "type", types), ", "))
+	for _, multiplier := range []float64{4, 2, 0.5, 0.25, 0} {
+		if attackTypes, ok := byMultiplier[multiplier]; ok {
+			fmt.Printf("  %vx from %s\n", multiplier, strings.Join(localNames(ctx, config, "type", attackTypes), ", "))
+		}
+	}
+	return nil
+}
+
+func commandMatchup(ctx context.Context, config *Config, params ...string) error {
+	if len(params) < 2 {
+		return errors.New("use matchup <attacker> <defender>")
+	}
+	chart, err := loadTypeChart(ctx, config)
+	if err != nil {
+		return err
+	}
+	var sides [2]PokemonEndpoint
+	var sideTypes [2][]string
+	for i, pokemon := range params[:2] {
+		sides[i], err = fetchPokemon(ctx, config, resolveSpecies(ctx, config, pokemon))
+		if err != nil {
+			return err
+		}
+		sideTypes[i], err = typesOf(ctx, config, sides[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range sides {
+		attacker, defender := i, 1-i
+		fmt.Printf("%s (%s) attacking %s (%s):\n",
+			localName(ctx, config, "pokemon-species", sides[attacker].Name),
+			strings.Join(localNames(ctx, config, "type", sideTypes[attacker]), ", "),
+			localName(ctx, config, "pokemon-species", sides[defender].Name),
+			strings.Join(localNames(ctx, config, "type", sideTypes[defender]), ", "))
+		for _, attackType := range sideTypes[attacker] {
+			fmt.Printf("  %s moves: %vx\n", localName(ctx, config, "type", attackType),
+				chart.effectiveness(attackType, sideTypes[defender]))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestTypeMultiplier(t *testing.T) {
+	config := newBattleConfig(t)
+
+	cases := []struct {
+		defender []string
+		expected float64
+	}{
+		{[]string{"normal"}, 1},
+		{[]string{"normal", "flying"}, 2},
+		{[]string{"water", "flying"}, 4},
+		{[]string{"grass", "flying"}, 1},
+		{[]string{"electric", "grass"}, 0.25},
+		{[]string{"ground", "flying"}, 0},
+	}
+	for _, c := range cases {
+		got, err := typeMultiplier(context.Background(), config, "electric", c.defender)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != c.expected {
+			t.Errorf("electric against %v: expected %v, got %v", c.defender, c.expected, got)
+		}
+	}
+}
+
+func TestTypesInGeneration(t *testing.T) {
+	clefairy := PokemonEndpoint{}
+	err := json.Unmarshal([]byte(`{
+		"name": "clefairy",
+		"types": [{"slot": 1, "type": {"name": "fairy"}}],
+		"past_types": [{
+			"generation": {"name": "generation-v"},
+			"types": [{"slot": 1, "type": {"name": "normal"}}]
+		}]
+	}`), &clefairy)
+	if err != nil {
+		t.Fatalf("decoding clefairy: %v", err)
+	}
+
+	cases := []struct {
+		generation int
+		expected   []string
+	}{
+		{0, []string{"fairy"}},
+		{1, []string{"normal"}},
+		{5, []string{"normal"}},
+		{6, []string{"fairy"}},
+	}
+	for _, c := range cases {
+		if got := typesInGeneration(clefairy, c.generation); !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("generation %d: expected %v, got %v", c.generation, c.expected, got)
+		}
+	}
+}
+
+func TestGenerationNumber(t *testing.T) {
+	cases := map[string]int{
+		"generation-i":    1,
+		"generation-iv":   4,
+		"generation-vi":   6,
+		"generation-viii": 8,
+		"generation-ix":   9,
+		"kanto":           0,
+	}
+	for name, expected := range cases {
+		if got := generationNumber(name); got != expected {
+			t.Errorf("%s: expected %d, got %d", name, expected, got)
+		}
+	}
+}
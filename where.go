@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// LocationAreaEncounter is one area a Pokemon can be encountered in, as
+// listed by the location_area_encounters URL of the Pokemon.
+type LocationAreaEncounter struct {
+	LocationArea   NamedAPIResource `json:"location_area"`
+	VersionDetails []struct {
+		MaxChance        int              `json:"max_chance"`
+		Version          NamedAPIResource `json:"version"`
+		EncounterDetails []struct {
+			Chance   int              `json:"chance"`
+			MinLevel int              `json:"min_level"`
+			MaxLevel int              `json:"max_level"`
+			Method   NamedAPIResource `json:"method"`
+		} `json:"encounter_details"`
+	} `json:"version_details"`
+}
+
+// methodSummary adds up the encounter slots of one method: the total
+// chance and the range of levels.
+type methodSummary struct {
+	Method   string
+	Chance   int
+	MinLevel int
+	MaxLevel int
+}
+
+func (s methodSummary) String() string {
+	levels := fmt.Sprintf("Lv. %d", s.MinLevel)
+	if s.MaxLevel > s.MinLevel {
+		levels = fmt.Sprintf("Lv. %d-%d", s.MinLevel, s.MaxLevel)
+	}
+	return fmt.Sprintf("%s %s %d%%", s.Method, levels, s.Chance)
+}
+
+// summarizeMethods merges the encounter slots of a version by method, in
+// the order the methods first appear.
+func summarizeMethods(encounter LocationAreaEncounter, version int) []methodSummary {
+	var summaries []methodSummary
+	index := make(map[string]int)
+	for _, detail := range encounter.VersionDetails[version].EncounterDetails {
+		maxLevel := max(detail.MaxLevel, detail.MinLevel)
+		i, ok := index[detail.Method.Name]
+		if !ok {
+			index[detail.Method.Name] = len(summaries)
+			summaries = append(summaries, methodSummary{
+				Method:   detail.Method.Name,
+				Chance:   detail.Chance,
+				MinLevel: detail.MinLevel,
+				MaxLevel: maxLevel,
+			})
+			continue
+		}
+		summaries[i].Chance += detail.Chance
+		summaries[i].MinLevel = min(summaries[i].MinLevel, detail.MinLevel)
+		summaries[i].MaxLevel = max(summaries[i].MaxLevel, maxLevel)
+	}
+	return summaries
+}
+
+func commandWhere(ctx context.Context, config *Config, params ...string) error {
+	if len(params) == 0 {
+		return errors.New("missing pokemon name")
+	}
+	data, err := fetchPokemon(ctx, config, resolveSpecies(ctx, config, params[0]))
+	if err != nil {
+		return err
+	}
+	var encounters []LocationAreaEncounter
+	err = fetchJSON(ctx, config, data.LocationAreaEncounters, &encounters)
+	if err != nil {
+		return err
+	}
+
+	name := localName(ctx, config, "pokemon-species", data.Name)
+	found := false
+	for _, encounter := range encounters {
+		var lines []string
+		for i, version := range encounter.VersionDetails {
+			if !inVersion(config, version.Version.Name) {
+				continue
+			}
+			var methods []string
+			for _, summary := range summarizeMethods(encounter, i) {
+				methods = append(methods, summary.String())
+			}
+			lines = append(lines, fmt.Sprintf("    %s: %s", version.Version.Name, strings.Join(methods, ", ")))
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		if !found {
+			fmt.Printf("%s can be found in:\n", name)
+			found = true
+		}
+		fmt.Println("-", localName(ctx, config, "location-area", encounter.LocationArea.Name))
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+	}
+	if !found {
+		if config.Version != "" {
+			fmt.Printf("%s can't be found in the wild in pokemon %s.\n", name, config.Version)
+		} else {
+			fmt.Printf("%s can't be found in the wild.\n", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeMethods(t *testing.T) {
+	var encounters []LocationAreaEncounter
+	err := json.Unmarshal([]byte(`[{
+		"location_area": {"name": "viridian-forest-area"},
+		"version_details": [{
+			"max_chance": 10,
+			"version": {"name": "red"},
+			"encounter_details": [
+				{"chance": 5, "min_level": 3, "max_level": 3, "method": {"name": "walk"}},
+				{"chance": 5, "min_level": 5, "max_level": 5, "method": {"name": "walk"}},
+				{"chance": 20, "min_level": 10, "max_level": 14, "method": {"name": "old-rod"}}
+			]
+		}]
+	}]`), &encounters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := summarizeMethods(encounters[0], 0)
+	expected := []methodSummary{
+		{Method: "walk", Chance: 10, MinLevel: 3, MaxLevel: 5},
+		{Method: "old-rod", Chance: 20, MinLevel: 10, MaxLevel: 14},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	if s := got[0].String(); s != "walk Lv. 3-5 10%" {
+		t.Errorf("unexpected summary %q", s)
+	}
+}